@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -11,37 +10,74 @@ import (
 	"time"
 
 	"github.com/jo3qma/protobuf/gen/go/yahoo_auction/v1/yahoo_auctionv1connect"
+	"jo3qma.com/yahoo_auctions/internal/config"
 	"jo3qma.com/yahoo_auctions/internal/handler"
 	"jo3qma.com/yahoo_auctions/internal/infrastructure/yahoo"
 	"jo3qma.com/yahoo_auctions/internal/usecase"
 )
 
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Invalid configuration: %v", err)
+	}
+
 	// 依存関係の組み立て（依存性注入）
 	// DBの代わりにScraperを注入することで、腐敗防止層のパターンを実現
-	auctionScraper := yahoo.NewYahooScraper()          // repository.ItemRepository
-	categoryScraper := yahoo.NewYahooCategoryScraper() // repository.CategoryItemRepository
+	scraperOpts := scraperOptions(cfg)
+
+	auctionScraper := yahoo.NewYahooScraper(scraperOpts...)          // repository.ItemRepository
+	categoryScraper := yahoo.NewYahooCategoryScraper(scraperOpts...) // repository.CategoryItemRepository
+	sellerScraper := yahoo.NewYahooSellerScraper(scraperOpts...)     // repository.SellerRepository
 
 	uc := usecase.NewAuctionUsecase(auctionScraper)
-	catUC := usecase.NewCategoryUsecase(categoryScraper)
+	catOpts := []usecase.CategoryOption{
+		usecase.WithSellerLookup(auctionScraper),
+		usecase.WithAllowedCategories(cfg.AllowedCategories),
+		usecase.WithDeniedCategories(cfg.DeniedCategories),
+		usecase.WithAggregateTimeout(cfg.AggregateTimeout),
+	}
+	if cfg.ShortPageRetry {
+		catOpts = append(catOpts, usecase.WithShortPageRetry(yahoo.CategoryItemsPerPage))
+	}
+	catUC := usecase.NewCategoryUsecase(categoryScraper, catOpts...)
+	sellerUC := usecase.NewSellerUsecase(sellerScraper)
 
 	h := handler.NewAuctionHandler(uc, catUC)
 
 	// Connectハンドラーの登録
 	mux := http.NewServeMux()
-	path, handler := yahoo_auctionv1connect.NewYahooAuctionServiceHandler(h)
-	mux.Handle(path, handler)
+	path, connectHandler := yahoo_auctionv1connect.NewYahooAuctionServiceHandler(h)
+	mux.Handle(path, connectHandler)
 
-	// HTTPサーバーの設定
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// protobufのサービス定義に含まれない軽量API
+	mux.Handle(handler.AuctionSummaryPattern, handler.NewAuctionSummaryHandler(uc))
+	mux.Handle(handler.BatchAuctionSummaryPattern, handler.NewBatchAuctionSummaryHandler(uc))
+	mux.Handle(handler.AuctionStatusPattern, handler.NewAuctionStatusHandler(uc))
+	mux.Handle(handler.WatchlistRefreshPattern, handler.NewWatchlistRefreshHandler(uc))
+	mux.Handle(handler.RelatedItemsPattern, handler.NewRelatedItemsHandler(uc))
+	mux.Handle(handler.AuctionQuestionsPattern, handler.NewAuctionQuestionsHandler(uc))
+	mux.Handle(handler.SellerRatingPattern, handler.NewSellerRatingHandler(sellerUC))
+	mux.Handle(handler.SellerItemCountPattern, handler.NewSellerItemCountHandler(sellerUC))
+	mux.Handle(handler.CategoryCSVPattern, handler.NewCategoryCSVHandler(catUC, cfg.CSVExportPages))
+	mux.Handle(handler.CategoryItemCountPattern, handler.NewCategoryItemCountHandler(catUC))
+
+	// 直近の商品詳細の抽出の成功率が下がった場合（HTML構造の変更など）に、ロードバランサーから外れるようにする
+	var readinessChecks []handler.ReadinessCheck
+	if hp, ok := auctionScraper.(yahoo.HealthProvider); ok {
+		readinessChecks = append(readinessChecks, func() error { return hp.Health().Err() })
 	}
-	addr := fmt.Sprintf(":%s", port)
+	mux.Handle(handler.ReadinessPattern, handler.NewReadinessHandler(readinessChecks...))
+
+	// HTTPサーバーの設定
+	addr := cfg.Addr()
+
+	// シャットダウン時に処理中のスクレイピングを完了させるため、リクエストを追跡する
+	drainer := handler.NewDrainer()
 
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      drainer.Wrap(mux),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -63,12 +99,55 @@ func main() {
 	log.Println("🛑 Shutting down server...")
 
 	// グレースフルシャットダウン
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
+	// 新しいリクエストを Unavailable で拒否しつつ、処理中のリクエストの完了を待つ
+	if err := drainer.Drain(ctx); err != nil {
+		log.Printf("⚠️  In-flight requests did not finish in time: %v", err)
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("❌ Server forced to shutdown: %v", err)
 	}
 
 	log.Println("✅ Server exited")
 }
+
+// scraperOptions は設定からスクレイパーのオプションを組み立てます
+func scraperOptions(cfg config.Config) []yahoo.Option {
+	opts := []yahoo.Option{
+		yahoo.WithHTTPClient(&http.Client{Timeout: cfg.HTTPTimeout}),
+		yahoo.WithMaxRetryAfter(cfg.MaxRetryAfter),
+	}
+	if cfg.ConditionalRequests {
+		opts = append(opts, yahoo.WithConditionalRequests())
+	}
+	if cfg.NormalizeText {
+		opts = append(opts, yahoo.WithTextNormalization())
+	}
+	if cfg.SkipImages {
+		opts = append(opts, yahoo.WithSkipImages())
+	}
+	if cfg.MobileFallback {
+		opts = append(opts, yahoo.WithMobileFallback())
+	}
+	if cfg.ClosedFallback {
+		opts = append(opts, yahoo.WithClosedAuctionFallback())
+	}
+	if cfg.VerifyHasNext {
+		opts = append(opts, yahoo.WithHasNextVerification())
+	}
+	if cfg.RequestCoalescing {
+		opts = append(opts, yahoo.WithRequestCoalescing())
+	}
+	if cfg.HealthWindow > 0 {
+		opts = append(opts, yahoo.WithExtractionHealth(cfg.HealthWindow, cfg.HealthMinRate, cfg.HealthMaxAge))
+	}
+	// DEBUG_DUMP_DIR を指定すると、取得したHTMLを調査用に書き出す（本番では通常無効）
+	if cfg.DebugDumpDir != "" {
+		log.Printf("⚠️  Debug dump enabled: %s", cfg.DebugDumpDir)
+		opts = append(opts, yahoo.WithDebugDump(cfg.DebugDumpDir))
+	}
+	return opts
+}
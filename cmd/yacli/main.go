@@ -0,0 +1,112 @@
+// Command yacli はヤフオクの商品・カテゴリ一覧を取得してJSONで標準出力に書き出すCLIです
+// サーバーを起動せずに抽出結果を確認するためのデバッグ・スクリプト用途のツールです
+//
+// 使い方:
+//
+//	yacli auction <オークションID または 商品URL>
+//	yacli category [-page N] [-keyword KEYWORD] <カテゴリID>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/yahoo"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+const usage = `usage:
+  yacli auction <auction ID or URL>
+  yacli category [-page N] [-keyword KEYWORD] <category ID>
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "yacli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run はサブコマンドを実行します
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("subcommand is required")
+	}
+
+	switch args[0] {
+	case "auction":
+		return runAuction(ctx, args[1:])
+	case "category":
+		return runCategory(ctx, args[1:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+// runAuction は商品詳細を取得して出力します
+func runAuction(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auction", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("auction ID or URL is required")
+	}
+
+	uc := usecase.NewAuctionUsecase(yahoo.NewYahooScraper())
+	item, err := uc.GetAuction(ctx, auctionIDFromArg(fs.Arg(0)))
+	if err != nil {
+		return err
+	}
+	return writeJSON(item)
+}
+
+// runCategory はカテゴリの商品一覧を1ページ取得して出力します
+func runCategory(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("category", flag.ContinueOnError)
+	page := fs.Int64("page", 0, "page number (0-based)")
+	keyword := fs.String("keyword", "", "keyword to narrow down the listing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("category ID is required")
+	}
+
+	uc := usecase.NewCategoryUsecase(yahoo.NewYahooCategoryScraper())
+	p, err := uc.GetCategoryItems(ctx, fs.Arg(0), *page, model.CategorySearchOptions{Keyword: *keyword})
+	if err != nil {
+		return err
+	}
+	return writeJSON(p)
+}
+
+// auctionIDFromArg は引数がURLの場合、パスの末尾からオークションIDを取り出します
+// 例: https://page.auctions.yahoo.co.jp/jp/auction/x1234567890 -> x1234567890
+func auctionIDFromArg(arg string) string {
+	u, err := url.Parse(arg)
+	if err != nil || u.Host == "" {
+		return arg
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	return segments[len(segments)-1]
+}
+
+// writeJSON は v を整形したJSONとして標準出力に書き出します
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -6,11 +6,16 @@ require (
 	connectrpc.com/connect v1.19.1
 	github.com/PuerkitoBio/goquery v1.11.0
 	github.com/jo3qma/protobuf/gen/go v0.0.0-20260104113818-386d7cf61954
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+	golang.org/x/net v0.47.0
+	golang.org/x/sync v0.18.0
+	golang.org/x/text v0.31.0
 	google.golang.org/protobuf v1.36.11
 )
 
 require (
 	github.com/andybalholm/cascadia v1.3.3 // indirect
 	github.com/envoyproxy/protoc-gen-validate v1.3.0 // indirect
-	golang.org/x/net v0.47.0 // indirect
 )
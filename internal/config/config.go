@@ -0,0 +1,208 @@
+// Package config は環境変数からアプリケーションの設定を読み込みます
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// デフォルト値
+const (
+	defaultPort             = 8080
+	defaultHTTPTimeout      = 30 * time.Second
+	defaultMaxRetryAfter    = 30 * time.Second
+	defaultShutdownTimeout  = 10 * time.Second
+	defaultCSVExportPages   = 10
+	defaultAggregateTimeout = 2 * time.Minute
+	defaultHealthWindow     = 100
+	defaultHealthMinRate    = 0.5
+	defaultHealthMaxAge     = 10 * time.Minute
+)
+
+// Config はサーバーとスクレイパーの設定です
+type Config struct {
+	Port             int           // PORT: 待ち受けポート
+	ShutdownTimeout  time.Duration // SHUTDOWN_TIMEOUT: グレースフルシャットダウンの猶予時間
+	CSVExportPages   int64         // CSV_EXPORT_MAX_PAGES: カテゴリのCSV出力で取得するページ数の上限
+	AggregateTimeout time.Duration // AGGREGATE_TIMEOUT: 複数ページを連続取得する処理全体の時間の上限。0 の場合は上限なし
+
+	AllowedCategories []string // ALLOWED_CATEGORIES: 取得を許可するカテゴリID（カンマ区切り）。空の場合はすべて許可する
+	DeniedCategories  []string // DENIED_CATEGORIES: 取得を拒否するカテゴリID（カンマ区切り）。許可リストより優先する
+	ShortPageRetry    bool     // SHORT_PAGE_RETRY: 複数ページの取得で、商品数が足りないのに後続の商品（総件数から判定）があるページを1回だけ取得し直す
+
+	HTTPTimeout         time.Duration // HTTP_TIMEOUT: ヤフオクへのリクエストのタイムアウト
+	MaxRetryAfter       time.Duration // MAX_RETRY_AFTER: 429 応答の Retry-After に従って待機する時間の上限
+	ConditionalRequests bool          // CONDITIONAL_REQUESTS: ETag / Last-Modified による条件付きリクエストを有効にする
+	NormalizeText       bool          // NORMALIZE_TEXT: タイトル・説明文に NFKC 正規化を適用する
+	SkipImages          bool          // SKIP_IMAGES: 商品詳細の画像の一覧を抽出しない
+	MobileFallback      bool          // MOBILE_FALLBACK: デスクトップ版で抽出に失敗した場合にモバイル版ページを試す
+	ClosedFallback      bool          // CLOSED_FALLBACK: 商品詳細ページが 404 / 410 の場合に終了済みオークションのページを試す
+	VerifyHasNext       bool          // VERIFY_HAS_NEXT: カテゴリ一覧の HasNext を総件数との比較で判定する
+	RequestCoalescing   bool          // REQUEST_COALESCING: 同じ商品を同時に取得する呼び出しを1回のリクエストにまとめる
+	DebugDumpDir        string        // DEBUG_DUMP_DIR: 取得したHTMLを書き出すディレクトリ。空の場合は書き出さない
+
+	HealthWindow  int           // HEALTH_WINDOW: /readyz の判定に使う直近の商品詳細の取得件数。0 の場合は判定しない
+	HealthMinRate float64       // HEALTH_MIN_SUCCESS_RATE: 直近の取得のうち、タイトルを抽出できた割合がこれを下回ると /readyz が失敗する
+	HealthMaxAge  time.Duration // HEALTH_MAX_AGE: これより古い取得は判定に使わない。/readyz の失敗でトラフィックが止まっても、この時間が経てば復帰する
+}
+
+// Default はデフォルト値の設定を返します
+func Default() Config {
+	return Config{
+		Port:             defaultPort,
+		ShutdownTimeout:  defaultShutdownTimeout,
+		CSVExportPages:   defaultCSVExportPages,
+		AggregateTimeout: defaultAggregateTimeout,
+		HTTPTimeout:      defaultHTTPTimeout,
+		MaxRetryAfter:    defaultMaxRetryAfter,
+		HealthWindow:     defaultHealthWindow,
+		HealthMinRate:    defaultHealthMinRate,
+		HealthMaxAge:     defaultHealthMaxAge,
+	}
+}
+
+// Load は環境変数から設定を読み込みます
+// 未設定の項目はデフォルト値となり、値が不正な場合はエラーを返します
+func Load() (Config, error) {
+	return load(os.Getenv)
+}
+
+// load は getenv から設定を読み込みます（テスト用に環境変数の取得方法を差し替えられます）
+func load(getenv func(string) string) (Config, error) {
+	cfg := Default()
+	var errs []error
+
+	if v := getenv("PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("PORT: %w", err))
+		}
+		cfg.Port = port
+	}
+	parseDuration(getenv, "SHUTDOWN_TIMEOUT", &cfg.ShutdownTimeout, &errs)
+	if v := getenv("CSV_EXPORT_MAX_PAGES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CSV_EXPORT_MAX_PAGES: %w", err))
+		}
+		cfg.CSVExportPages = n
+	}
+	parseDuration(getenv, "AGGREGATE_TIMEOUT", &cfg.AggregateTimeout, &errs)
+	cfg.AllowedCategories = parseList(getenv("ALLOWED_CATEGORIES"))
+	cfg.DeniedCategories = parseList(getenv("DENIED_CATEGORIES"))
+	parseBool(getenv, "SHORT_PAGE_RETRY", &cfg.ShortPageRetry, &errs)
+	parseDuration(getenv, "HTTP_TIMEOUT", &cfg.HTTPTimeout, &errs)
+	parseDuration(getenv, "MAX_RETRY_AFTER", &cfg.MaxRetryAfter, &errs)
+	parseBool(getenv, "CONDITIONAL_REQUESTS", &cfg.ConditionalRequests, &errs)
+	parseBool(getenv, "NORMALIZE_TEXT", &cfg.NormalizeText, &errs)
+	parseBool(getenv, "SKIP_IMAGES", &cfg.SkipImages, &errs)
+	parseBool(getenv, "MOBILE_FALLBACK", &cfg.MobileFallback, &errs)
+	parseBool(getenv, "CLOSED_FALLBACK", &cfg.ClosedFallback, &errs)
+	parseBool(getenv, "VERIFY_HAS_NEXT", &cfg.VerifyHasNext, &errs)
+	parseBool(getenv, "REQUEST_COALESCING", &cfg.RequestCoalescing, &errs)
+	cfg.DebugDumpDir = getenv("DEBUG_DUMP_DIR")
+	if v := getenv("HEALTH_WINDOW"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("HEALTH_WINDOW: %w", err))
+		}
+		cfg.HealthWindow = n
+	}
+	if v := getenv("HEALTH_MIN_SUCCESS_RATE"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("HEALTH_MIN_SUCCESS_RATE: %w", err))
+		}
+		cfg.HealthMinRate = rate
+	}
+	parseDuration(getenv, "HEALTH_MAX_AGE", &cfg.HealthMaxAge, &errs)
+
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate は設定値の範囲を検証します
+func (c Config) Validate() error {
+	var errs []error
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: must be between 1 and 65535, got %d", c.Port))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("SHUTDOWN_TIMEOUT: must be positive, got %s", c.ShutdownTimeout))
+	}
+	if c.CSVExportPages < 1 {
+		errs = append(errs, fmt.Errorf("CSV_EXPORT_MAX_PAGES: must be positive, got %d", c.CSVExportPages))
+	}
+	if c.AggregateTimeout < 0 {
+		errs = append(errs, fmt.Errorf("AGGREGATE_TIMEOUT: must not be negative, got %s", c.AggregateTimeout))
+	}
+	if c.HTTPTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_TIMEOUT: must be positive, got %s", c.HTTPTimeout))
+	}
+	if c.MaxRetryAfter < 0 {
+		errs = append(errs, fmt.Errorf("MAX_RETRY_AFTER: must not be negative, got %s", c.MaxRetryAfter))
+	}
+	if c.HealthWindow < 0 {
+		errs = append(errs, fmt.Errorf("HEALTH_WINDOW: must not be negative, got %d", c.HealthWindow))
+	}
+	if c.HealthMinRate < 0 || c.HealthMinRate > 1 {
+		errs = append(errs, fmt.Errorf("HEALTH_MIN_SUCCESS_RATE: must be between 0 and 1, got %g", c.HealthMinRate))
+	}
+	if c.HealthMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("HEALTH_MAX_AGE: must not be negative, got %s", c.HealthMaxAge))
+	}
+	return errors.Join(errs...)
+}
+
+// Addr はサーバーの待ち受けアドレスを返します
+func (c Config) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// parseDuration は key の値を time.Duration として dst に設定します。未設定の場合は何もしません
+func parseDuration(getenv func(string) string, key string, dst *time.Duration, errs *[]error) {
+	v := getenv(key)
+	if v == "" {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+		return
+	}
+	*dst = d
+}
+
+// parseBool は key の値を bool として dst に設定します。未設定の場合は何もしません
+func parseBool(getenv func(string) string, key string, dst *bool, errs *[]error) {
+	v := getenv(key)
+	if v == "" {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+		return
+	}
+	*dst = b
+}
+
+// parseList はカンマ区切りの値を分割し、前後の空白を除いた空でない要素を返します
+func parseList(v string) []string {
+	var list []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			list = append(list, p)
+		}
+	}
+	return list
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{},
+			want: Default(),
+		},
+		{
+			name: "all values",
+			env: map[string]string{
+				"PORT":                    "9090",
+				"SHUTDOWN_TIMEOUT":        "20s",
+				"CSV_EXPORT_MAX_PAGES":    "3",
+				"AGGREGATE_TIMEOUT":       "30s",
+				"ALLOWED_CATEGORIES":      "2084005, 2084261685,",
+				"DENIED_CATEGORIES":       "2084060731",
+				"SHORT_PAGE_RETRY":        "true",
+				"HTTP_TIMEOUT":            "5s",
+				"MAX_RETRY_AFTER":         "1m",
+				"CONDITIONAL_REQUESTS":    "true",
+				"NORMALIZE_TEXT":          "1",
+				"SKIP_IMAGES":             "true",
+				"MOBILE_FALLBACK":         "true",
+				"CLOSED_FALLBACK":         "true",
+				"VERIFY_HAS_NEXT":         "true",
+				"REQUEST_COALESCING":      "true",
+				"DEBUG_DUMP_DIR":          "/tmp/dump",
+				"HEALTH_WINDOW":           "50",
+				"HEALTH_MIN_SUCCESS_RATE": "0.8",
+				"HEALTH_MAX_AGE":          "5m",
+			},
+			want: Config{
+				Port:                9090,
+				ShutdownTimeout:     20 * time.Second,
+				CSVExportPages:      3,
+				AggregateTimeout:    30 * time.Second,
+				AllowedCategories:   []string{"2084005", "2084261685"},
+				DeniedCategories:    []string{"2084060731"},
+				ShortPageRetry:      true,
+				HTTPTimeout:         5 * time.Second,
+				MaxRetryAfter:       time.Minute,
+				ConditionalRequests: true,
+				NormalizeText:       true,
+				SkipImages:          true,
+				MobileFallback:      true,
+				ClosedFallback:      true,
+				VerifyHasNext:       true,
+				RequestCoalescing:   true,
+				DebugDumpDir:        "/tmp/dump",
+				HealthWindow:        50,
+				HealthMinRate:       0.8,
+				HealthMaxAge:        5 * time.Minute,
+			},
+		},
+		{name: "invalid port", env: map[string]string{"PORT": "http"}, wantErr: true},
+		{name: "port out of range", env: map[string]string{"PORT": "70000"}, wantErr: true},
+		{name: "non-positive csv pages", env: map[string]string{"CSV_EXPORT_MAX_PAGES": "0"}, wantErr: true},
+		{name: "negative aggregate timeout", env: map[string]string{"AGGREGATE_TIMEOUT": "-1s"}, wantErr: true},
+		{name: "invalid duration", env: map[string]string{"HTTP_TIMEOUT": "5"}, wantErr: true},
+		{name: "non-positive timeout", env: map[string]string{"HTTP_TIMEOUT": "0s"}, wantErr: true},
+		{name: "negative health window", env: map[string]string{"HEALTH_WINDOW": "-1"}, wantErr: true},
+		{name: "health rate out of range", env: map[string]string{"HEALTH_MIN_SUCCESS_RATE": "1.5"}, wantErr: true},
+		{name: "negative health max age", env: map[string]string{"HEALTH_MAX_AGE": "-1m"}, wantErr: true},
+		{name: "invalid bool", env: map[string]string{"NORMALIZE_TEXT": "yes"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := load(func(key string) string { return tc.env[key] })
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Addr(t *testing.T) {
+	t.Parallel()
+
+	if got := (Config{Port: 8080}).Addr(); got != ":8080" {
+		t.Fatalf("got %q, want %q", got, ":8080")
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+// BidRestriction は入札できる利用者の制限を表します
+type BidRestriction int32
+
+const (
+	BidRestrictionNone         BidRestriction = iota // 制限なし（表示がない場合を含む）
+	BidRestrictionVerifiedOnly                       // 本人確認済みの利用者のみ入札できる
+	BidRestrictionPremiumOnly                        // Yahoo!プレミアム会員のみ入札できる
+)
+
+// String は入札制限の名前を返します
+func (r BidRestriction) String() string {
+	switch r {
+	case BidRestrictionVerifiedOnly:
+		return "verified_only"
+	case BidRestrictionPremiumOnly:
+		return "premium_only"
+	default:
+		return "none"
+	}
+}
@@ -1,14 +1,28 @@
 package model
 
+import "time"
+
 // CategoryItem はカテゴリ一覧で取得される商品のドメインモデルです
 // 詳細情報（Item）よりも軽量な情報のみを持ちます
 type CategoryItem struct {
 	AuctionID      string
 	Title          string
-	CurrentPrice   int64  // 現在価格（単位：円）
-	ImmediatePrice int64  // 即決価格（単位：円）。ない場合は0
-	BidCount       int64  // 入札数
-	Image          string // 商品画像のURL（一覧用サムネイルなど）
+	CurrentPrice   int64     // 現在価格（単位：円）
+	ImmediatePrice int64     // 即決価格（単位：円）。ない場合は0
+	StartPrice     int64     // 開始価格（単位：円）。一覧に表示されない場合は0
+	TotalPrice     int64     // 送料込みの価格（単位：円）。送料が不明な場合は CurrentPrice と同じ
+	BidCount       int64     // 入札数
+	Image          string    // 商品画像のURL（一覧用サムネイルなど）
+	StartTime      time.Time // 開始日時。一覧に表示されない場合はゼロ値
+	EndTime        time.Time // 終了日時。取得できない場合はゼロ値
+	Condition      Condition // 商品の状態。一覧に表示されない場合は ConditionUnspecified
+	Quantity       int64     // 残りの個数（ストアの出品など）。一覧に表示されない場合は1
+	// Rank はヤフオクが一覧に表示した順位です（1 始まり）。取得位置（offset）を加味したカテゴリ全体での順位で、
+	// 並べ替えや絞り込みを行っても変わりません。一覧以外（関連商品など）から取得した場合は0
+	Rank int
+	// ShippingPayer は送料の負担者です。一覧に表示されない場合は ShippingPayerUnknown
+	// 落札者負担の場合、TotalPrice（送料が表示されている場合）が落札者の支払額の目安になります
+	ShippingPayer ShippingPayer
 }
 
 // CategoryItemsPage はカテゴリ商品一覧のページネーション結果を表します
@@ -16,4 +30,48 @@ type CategoryItemsPage struct {
 	Items      []*CategoryItem
 	TotalCount int64 // 商品の総数
 	HasNext    bool  // 次のページがあるかどうか（簡易判定用）
+	// Truncated は複数ページの取得が時間の上限に達し、途中までの結果であることを示します
+	Truncated bool
+	// NoResults は取得元が「該当する商品がありません」と表示したことを示します
+	// Items が空でも NoResults が false の場合は、ページ構造の変化などで商品を抽出できなかった可能性があります
+	NoResults bool
+	// Partial は取得の一部（出品者の評価の確認など）に失敗し、判定できなかった商品を除いた結果であることを示します
+	// Items が空でも Partial が true の場合は、条件に合う商品がないとは限りません
+	Partial bool
+	// FailedCategoryIDs は複数カテゴリの統合で取得に失敗し、結果に含まれていないカテゴリIDです
+	// 空でない場合は Partial も true になります
+	FailedCategoryIDs []string
+}
+
+// CategoryPageLimits はカテゴリ一覧の1回の取得で指定できる件数です
+// 取得元（ヤフオク）が受け付ける表示件数に合わせています
+var CategoryPageLimits = []int64{20, 50, 100}
+
+// MaxNewlyListedWithin は CategorySearchOptions.NewlyListedWithin に指定できる期間の上限です
+// 取得元の新着フィルタが対象とする期間（24時間）に合わせています
+const MaxNewlyListedWithin = 24 * time.Hour
+
+// CategorySearchOptions はカテゴリ商品一覧を取得する際の任意の検索条件です
+// ゼロ値の場合は条件なし（カテゴリ内の全商品）として扱います
+type CategorySearchOptions struct {
+	Keyword          string   // カテゴリ内で絞り込む検索キーワード。空の場合は指定しない
+	ExcludeKeywords  []string // 結果から除外するキーワード（例: "ジャンク"）。空の場合は指定しない
+	EndingSoon       bool     // 終了時間の近い順に並べ、終了済みの商品を除外する
+	FreeShippingOnly bool     // 送料無料の商品のみに絞り込む
+	// NewlyListedWithin は出品からの経過時間で新着商品に絞り込みます。0 の場合は絞り込まない
+	// MaxNewlyListedWithin（24時間）を超える期間は指定できません。24時間未満の場合は、取得元の新着フィルタの結果を
+	// 一覧の開始日時で取得したページ内でさらに絞り込みます（開始日時が表示されない商品は除外しません）
+	NewlyListedWithin time.Duration
+	// MinSellerRatingPercentage は出品者の良い評価の割合（0〜100）の下限です。0 の場合は絞り込まない
+	// 取得元では絞り込めないため、一覧の商品ごとに詳細ページを取得して判定します（1ページあたり最大で商品数と同じ回数のリクエストが追加で発生します）
+	MinSellerRatingPercentage float64
+	// SortBy は取得後に商品を並べ替えるキーです。SortKeyNone の場合は取得元の並び順のままとします
+	// 取得したページ内でのみ並べ替えるため、カテゴリ全体での並び順にはなりません（SortItems を参照）
+	SortBy SortKey
+	// SellerType は出品者の種別（ストア・個人）で絞り込みます。SellerTypeAny の場合は絞り込まない
+	SellerType SellerType
+	// MaxImmediatePriceRatio は即決価格に対する現在価格の割合（0〜1）の上限です。0 の場合は絞り込まない
+	// 即決価格があり、現在価格が「即決価格 × MaxImmediatePriceRatio」未満の商品のみを残します（例: 0.5 で即決価格の半額未満）
+	// 取得元では絞り込めないため、取得したページ内でのみ絞り込みます（1ページの商品数は指定した件数より少なくなります）
+	MaxImmediatePriceRatio float64
 }
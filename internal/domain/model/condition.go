@@ -0,0 +1,35 @@
+package model
+
+// Condition は商品の状態を表します
+// 値が小さいほど状態が良く、状態での絞り込みや並べ替えに利用できます（ConditionUnspecified を除く）
+type Condition int32
+
+const (
+	ConditionUnspecified     Condition = iota // 不明
+	ConditionNew                              // 新品・未使用
+	ConditionLikeNew                          // 未使用に近い
+	ConditionNoVisibleDamage                  // 目立った傷や汚れなし
+	ConditionMinorDamage                      // やや傷や汚れあり
+	ConditionDamaged                          // 傷や汚れあり
+	ConditionPoor                             // 全体的に状態が悪い
+)
+
+// String は状態の名前を返します
+func (c Condition) String() string {
+	switch c {
+	case ConditionNew:
+		return "new"
+	case ConditionLikeNew:
+		return "like_new"
+	case ConditionNoVisibleDamage:
+		return "no_visible_damage"
+	case ConditionMinorDamage:
+		return "minor_damage"
+	case ConditionDamaged:
+		return "damaged"
+	case ConditionPoor:
+		return "poor"
+	default:
+		return "unspecified"
+	}
+}
@@ -1,18 +1,129 @@
 package model
 
-import "time"
+import (
+	"maps"
+	"slices"
+	"time"
+)
 
 // Item はオークション商品のドメインモデルです
 // 外部サイト（ヤフオク）のHTML構造を知らない、純粋なデータ構造を定義します
 type Item struct {
 	AuctionID    string
 	Title        string
-	CurrentPrice int64               // 現在価格（単位：円）
+	CurrentPrice int64               // 現在価格（単位：円）。終了済みの場合は落札価格と同じ
+	FinalPrice   int64               // 落札価格（単位：円）。終了済み（StatusFinished）の場合のみ設定され、それ以外は0
 	ShippingFee  int64               // 送料（単位：円）
+	BidCount     int64               // 入札件数
 	Status       Status              // オークションの状態
 	Images       []string            // 商品画像のURLリスト
+	Thumbnail    string              // 一覧のカードなどに使うメイン画像のURL。メイン画像の指定がない場合は Images の先頭
 	AuctionInfo  *AuctionInformation // オークション情報
 	Description  string              // 商品説明（HTML）
+	Seller       *Seller             // 出品者情報
+	RelatedItems []*CategoryItem     // 関連商品（おすすめ）。ない場合は空スライス
+	Questions    []*QA               // 公開されている質問と回答。ない場合は空スライス
+	Variations   []*Variation        // ストア出品のサイズ・色などの選択肢。ない場合は空スライス
+	ShippingDays string              // 発送までの日数（例: "1～2日で発送"）。不明な場合は空
+	ShippingSize string              // 荷物のサイズ（例: "80サイズ"）。送料の見積もりに利用します。出品者が指定していない場合は空
+	Condition    Condition           // 商品の状態
+	Tags         []string            // 出品に付けられた検索用のタグ。ない場合は空スライス
+	ItemLocation string              // 出品地域（商品の所在地）。発送元の地域（Seller.Location）とは別の値。不明な場合は空
+	ProductCode  string              // ストアが独自に管理する商品コード（オークションIDとは別の値）。ストア以外の出品など、ない場合は空
+
+	ShippingWeight int64 // 荷物の重量（単位：グラム）。出品者が指定していない場合は0
+
+	ShipsInternationally bool // 海外発送に対応しているか。表示がない場合は false
+	IsRelisted           bool // 再出品されたオークションか。判定できない場合は false
+	IsFeatured           bool // 出品者が有料の「注目のオークション」を設定しているか。判定できない場合は false
+
+	// ImmediateBuyAvailable は現時点で即決価格での落札ができるかを表します
+	// 即決価格があっても、入札が入ると即決できなくなる場合があります。終了済みの場合は false
+	ImmediateBuyAvailable bool
+
+	BidRestriction BidRestriction // 入札できる利用者の制限。制限がない場合は BidRestrictionNone
+
+	// 需要の目安となる人数です。ページに表示がない場合は0
+	WatchCount      int64 // ウォッチリストに追加している人数（ウォッチ）
+	ViewingNowCount int64 // 現在この商品を見ている人数（この商品を見ている人）。WatchCount とは別の値
+
+	// ImageMetadata は取得後の後処理で画像から求めたメタデータです（例: "dominant_color"）。付与されていない場合は nil
+	ImageMetadata map[string]string
+
+	HasCoupon         bool   // ストアのクーポンが利用できるか
+	CouponDescription string // クーポンの内容。複数ある場合は " / " 区切り。ない場合は空
+
+	DescriptionText string // 商品説明（HTMLタグを除いたテキスト）
+
+	// 文字幅の正規化（NFKC）が有効な場合、Title と DescriptionText は正規化後の値となり、
+	// 正規化前の値は以下に保持されます。正規化が無効な場合は空です
+	RawTitle           string
+	RawDescriptionText string
+}
+
+// Clone は i の複製を返します
+// ポインタ・スライス・マップのフィールドも複製するため、複製側を書き換えても i には影響しません。i が nil の場合は nil です
+func (i *Item) Clone() *Item {
+	if i == nil {
+		return nil
+	}
+
+	c := *i
+	c.Images = slices.Clone(i.Images)
+	c.Tags = slices.Clone(i.Tags)
+	c.ImageMetadata = maps.Clone(i.ImageMetadata)
+	c.AuctionInfo = clonePtr(i.AuctionInfo)
+	c.Seller = clonePtr(i.Seller)
+	c.RelatedItems = clonePtrs(i.RelatedItems)
+	c.Questions = clonePtrs(i.Questions)
+	c.Variations = clonePtrs(i.Variations)
+	return &c
+}
+
+// clonePtr は p が指す値の複製へのポインタを返します。p が nil の場合は nil です
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// clonePtrs は s の各要素が指す値を複製したスライスを返します。s が nil の場合は nil、空の場合は空スライスです
+func clonePtrs[T any](s []*T) []*T {
+	if s == nil {
+		return nil
+	}
+	c := make([]*T, len(s))
+	for i, p := range s {
+		c[i] = clonePtr(p)
+	}
+	return c
+}
+
+// QA は商品ページで公開されている質問と、出品者の回答を表します
+type QA struct {
+	Question   string    // 質問の本文
+	Answer     string    // 回答の本文。未回答の場合は空
+	AskedAt    time.Time // 質問日時。取得できない場合はゼロ値
+	AnsweredAt time.Time // 回答日時。未回答または取得できない場合はゼロ値
+}
+
+// Variation はストア出品で選択できるサイズ・色などの選択肢を表します
+type Variation struct {
+	Name  string // 選択肢の名前（例: "ブラック / M"）
+	Price int64  // 価格（単位：円）。選択肢ごとの価格がない場合は0
+	Stock int64  // 在庫数。不明な場合は0
+}
+
+// Seller は出品者の情報を表します
+type Seller struct {
+	ID               string  // 出品者ID
+	Name             string  // 表示名
+	GoodRating       int64   // 良い評価の数
+	BadRating        int64   // 悪い評価の数
+	RatingPercentage float64 // 良い評価の割合（0〜100）。評価がない場合は0
+	Location         string  // 発送元の地域（都道府県など）。不明な場合は空
 }
 
 // AuctionInformation はオークションの詳細情報を表します
@@ -25,6 +136,26 @@ type AuctionInformation struct {
 	AutoExtension    bool      // 自動延長
 	Returnable       bool      // 返品の可否
 	ReturnableDetail string    // 返品の可否（詳細）
+	TotalAccessCount int64     // 出品から現在（終了済みの場合は終了）までの累計アクセス数。表示がない場合は0
+}
+
+// AuctionSummary は価格監視などのポーリング用途向けに、商品情報のうち軽量なフィールドのみを持ちます
+type AuctionSummary struct {
+	AuctionID    string
+	Title        string
+	CurrentPrice int64     // 現在価格（単位：円）
+	Status       Status    // オークションの状態
+	EndTime      time.Time // 終了日時
+}
+
+// AuctionSnapshot は価格推移の記録用に、ある時点の現在価格・入札件数・終了日時の組を表します
+// 価格の追跡・ポーリングで扱う最小単位です
+type AuctionSnapshot struct {
+	AuctionID    string
+	CurrentPrice int64     // 現在価格（単位：円）
+	BidCount     int64     // 入札件数
+	EndTime      time.Time // 終了日時（自動延長により変わることがあります）
+	Status       Status    // オークションの状態
 }
 
 // Status はオークションの状態を表します
@@ -36,3 +167,25 @@ const (
 	StatusFinished    Status = 2 // 終了済み（落札または時間切れ）
 	StatusCanceled    Status = 3 // 出品者都合などでキャンセルされた状態
 )
+
+// ItemFields は商品情報のうち取得対象とする任意フィールドの集合です
+// 説明文や画像など抽出コストの高いフィールドを省略したい場合に利用します
+type ItemFields uint32
+
+const (
+	ItemFieldDescription  ItemFields = 1 << iota // 商品説明（HTML）
+	ItemFieldImages                              // 商品画像のURLリスト
+	ItemFieldSeller                              // 出品者情報
+	ItemFieldRelatedItems                        // 関連商品
+	ItemFieldQuestions                           // 質問と回答
+
+	// ItemFieldsNone は任意フィールドを一切取得しないことを表します
+	ItemFieldsNone ItemFields = 0
+	// ItemFieldsAll はすべての任意フィールドを取得することを表します
+	ItemFieldsAll = ItemFieldDescription | ItemFieldImages | ItemFieldSeller | ItemFieldRelatedItems | ItemFieldQuestions
+)
+
+// Has は f が指定したフィールドをすべて含むかどうかを返します
+func (f ItemFields) Has(field ItemFields) bool {
+	return f&field == field
+}
@@ -0,0 +1,14 @@
+package model
+
+// Result は複数の入力をまとめて処理する場合の、入力1件ごとの結果です
+// 一部の入力が失敗しても残りの結果を返せるよう、値とエラーのいずれかを保持します
+type Result[T any] struct {
+	ID    string // 入力のID（オークションIDなど）
+	Value T      // 成功した場合の値。Err が nil でない場合はゼロ値
+	Err   error  // 失敗した場合のエラー
+}
+
+// OK は処理が成功したかどうかを返します
+func (r Result[T]) OK() bool {
+	return r.Err == nil
+}
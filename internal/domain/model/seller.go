@@ -0,0 +1,17 @@
+package model
+
+// RatingCounts は評価の内訳（件数）です
+type RatingCounts struct {
+	Good   int64 // 良い評価（「非常に良い」「良い」の合計）
+	Normal int64 // どちらでもない
+	Bad    int64 // 悪い評価（「悪い」「非常に悪い」の合計）
+}
+
+// SellerRating は出品者の評価詳細ページの情報です
+// 評価がない出品者の場合、各件数はすべて0です
+type SellerRating struct {
+	SellerID     string
+	Last6Months  RatingCounts // 過去6ヶ月
+	Last12Months RatingCounts // 過去12ヶ月
+	Total        RatingCounts // 全期間
+}
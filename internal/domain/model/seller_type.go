@@ -0,0 +1,42 @@
+package model
+
+// SellerType はカテゴリ商品一覧で絞り込む出品者の種別です
+type SellerType int32
+
+const (
+	SellerTypeAny        SellerType = iota // 絞り込まない（ストア・個人の両方）
+	SellerTypeStore                        // ストアの出品のみ
+	SellerTypeIndividual                   // 個人の出品のみ
+)
+
+// sellerTypeNames は SellerType の名前です
+var sellerTypeNames = map[SellerType]string{
+	SellerTypeAny:        "any",
+	SellerTypeStore:      "store",
+	SellerTypeIndividual: "individual",
+}
+
+// String は出品者の種別の名前を返します
+func (t SellerType) String() string {
+	if name, ok := sellerTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Valid は定義済みの出品者の種別かどうかを返します
+func (t SellerType) Valid() bool {
+	_, ok := sellerTypeNames[t]
+	return ok
+}
+
+// ParseSellerType は名前（"store" など）から出品者の種別を返します
+// 該当する名前がない場合は false を返します
+func ParseSellerType(name string) (SellerType, bool) {
+	for t, n := range sellerTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return SellerTypeAny, false
+}
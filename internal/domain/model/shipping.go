@@ -0,0 +1,22 @@
+package model
+
+// ShippingPayer は送料を負担する側を表します
+type ShippingPayer int32
+
+const (
+	ShippingPayerUnknown ShippingPayer = iota // 不明（一覧に表示されない場合など）
+	ShippingPayerSeller                       // 出品者負担（送料無料）
+	ShippingPayerBuyer                        // 落札者負担
+)
+
+// String は送料負担者の名前を返します
+func (p ShippingPayer) String() string {
+	switch p {
+	case ShippingPayerSeller:
+		return "seller"
+	case ShippingPayerBuyer:
+		return "buyer"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import "sort"
+
+// SortKey は取得済みのカテゴリ商品一覧を並べ替えるキーです
+// 取得元の並び順では要件を満たせない場合に利用します
+type SortKey int32
+
+const (
+	SortKeyNone         SortKey = iota // 並べ替えない（取得元の並び順）
+	SortKeyPriceAsc                    // 現在価格の安い順
+	SortKeyPriceDesc                   // 現在価格の高い順
+	SortKeyBidCountAsc                 // 入札数の少ない順
+	SortKeyBidCountDesc                // 入札数の多い順
+	SortKeyEndTimeAsc                  // 終了日時の近い順。終了日時が不明な商品は末尾
+)
+
+// sortKeyNames は SortKey の名前です
+var sortKeyNames = map[SortKey]string{
+	SortKeyNone:         "none",
+	SortKeyPriceAsc:     "price_asc",
+	SortKeyPriceDesc:    "price_desc",
+	SortKeyBidCountAsc:  "bid_count_asc",
+	SortKeyBidCountDesc: "bid_count_desc",
+	SortKeyEndTimeAsc:   "end_time_asc",
+}
+
+// String は並べ替えキーの名前を返します
+func (k SortKey) String() string {
+	if name, ok := sortKeyNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Valid は定義済みの並べ替えキーかどうかを返します
+func (k SortKey) Valid() bool {
+	_, ok := sortKeyNames[k]
+	return ok
+}
+
+// ParseSortKey は名前（"price_asc" など）から並べ替えキーを返します
+// 該当する名前がない場合は false を返します
+func ParseSortKey(name string) (SortKey, bool) {
+	for k, n := range sortKeyNames {
+		if n == name {
+			return k, true
+		}
+	}
+	return SortKeyNone, false
+}
+
+// SortItems は page の商品を key の順に並べ替えたページを返します。page 自体は変更しません
+// 並べ替えは取得済みのページ内のみで行われ、カテゴリ全体での順位にはなりません
+// （例えば入札数の少ない順でも、次のページにさらに入札数の少ない商品が含まれることがあります）
+// 値が同じ商品は元の並び順を保ちます
+func SortItems(page *CategoryItemsPage, key SortKey) *CategoryItemsPage {
+	if page == nil || key == SortKeyNone {
+		return page
+	}
+
+	items := append([]*CategoryItem(nil), page.Items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch key {
+		case SortKeyPriceAsc:
+			return a.CurrentPrice < b.CurrentPrice
+		case SortKeyPriceDesc:
+			return a.CurrentPrice > b.CurrentPrice
+		case SortKeyBidCountAsc:
+			return a.BidCount < b.BidCount
+		case SortKeyBidCountDesc:
+			return a.BidCount > b.BidCount
+		case SortKeyEndTimeAsc:
+			if a.EndTime.IsZero() || b.EndTime.IsZero() {
+				return !a.EndTime.IsZero() && b.EndTime.IsZero()
+			}
+			return a.EndTime.Before(b.EndTime)
+		default:
+			return false
+		}
+	})
+
+	sorted := *page
+	sorted.Items = items
+	return &sorted
+}
@@ -10,5 +10,15 @@ import (
 type CategoryItemRepository interface {
 	// FetchByCategory は指定されたカテゴリIDから商品一覧を取得します
 	// page は 0 始まりのページ番号です
-	FetchByCategory(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error)
+	// opts で検索キーワードなどの絞り込み条件を指定できます
+	FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error)
+
+	// FetchByCategoryOffset は指定されたカテゴリIDから、offset 件目（0 始まり）以降の商品を limit 件取得します
+	// クロールを中断・再開する場合に、取得位置をそのまま保存・指定するために利用します
+	// limit は model.CategoryPageLimits のいずれかです
+	FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error)
+
+	// FetchItemCount は指定されたカテゴリIDの商品の総数のみを取得します
+	// 各商品の抽出を行わないため、FetchByCategory よりも軽量です
+	FetchItemCount(ctx context.Context, categoryID string) (int64, error)
 }
@@ -0,0 +1,25 @@
+package repository
+
+import "errors"
+
+// ErrServiceUnavailable は取得元がメンテナンス中などで一時的に利用できない場合に返されます
+// 恒久的な失敗（商品が存在しない等）とは区別し、呼び出し側は時間をおいて再試行できます
+var ErrServiceUnavailable = errors.New("service unavailable")
+
+// ErrChallengeRequired は取得元がCAPTCHAなどの確認ページを返し、通過できなかった場合に返されます
+// 確認を通過する処理（ChallengeSolver など）が設定されていない場合もこのエラーになります
+var ErrChallengeRequired = errors.New("challenge required")
+
+// ErrNotFound は取得元に対象（商品ページなど）が存在しない場合に返されます
+// 終了後に削除されたオークションなど、再試行しても結果が変わらない失敗です
+var ErrNotFound = errors.New("not found")
+
+// ErrRateLimited は取得元がリクエスト数の制限（429 Too Many Requests）を返し、再試行できなかった場合に返されます
+// 対象が存在しないことを示すものではないため、呼び出し側は時間をおいて再試行できます
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrUnexpectedStatus は取得元が想定外のステータスコード（5xx など）を返した場合に返されます
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+// ErrFetchFailed は通信エラーなどにより取得元から応答を得られなかった場合に返されます
+var ErrFetchFailed = errors.New("fetch failed")
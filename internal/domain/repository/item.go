@@ -12,4 +12,12 @@ import (
 type ItemRepository interface {
 	// FetchByID は指定されたオークションIDから商品情報を取得します
 	FetchByID(ctx context.Context, auctionID string) (*model.Item, error)
+
+	// FetchByIDWithFields は fields で指定した任意フィールドのみを抽出して商品情報を取得します
+	// 指定されなかったフィールドはゼロ値のままとなります
+	FetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error)
+
+	// FetchStatus は指定されたオークションIDの状態のみを取得します
+	// 商品情報全体の抽出を行わないため、FetchByID よりも軽量です
+	FetchStatus(ctx context.Context, auctionID string) (model.Status, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// SellerRepository は出品者情報の取得方法を抽象化します
+type SellerRepository interface {
+	// FetchSellerRating は指定された出品者IDの評価の内訳を取得します
+	FetchSellerRating(ctx context.Context, sellerID string) (*model.SellerRating, error)
+
+	// FetchSellerItemCount は指定された出品者IDの出品中の商品の総数を取得します
+	FetchSellerItemCount(ctx context.Context, sellerID string) (int64, error)
+}
@@ -2,22 +2,86 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	yahoo_auctionv1 "github.com/jo3qma/protobuf/gen/go/yahoo_auction/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
 )
 
 // AuctionGetter はオークション取得ユースケースの最小インターフェースです。
 // handler層は具象（usecase.AuctionUsecase）に依存せず、境界変換に集中します。
 type AuctionGetter interface {
 	GetAuction(ctx context.Context, auctionID string) (*model.Item, error)
+	GetAuctionWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error)
 }
 
+// FieldsHeader は GetAuction で取得する任意フィールドを指定するリクエストヘッダーです
+// "description,images,seller,related_items,questions" のようにカンマ区切りで指定します。省略時はすべてのフィールドを取得します
+const FieldsHeader = "X-Auction-Fields"
+
+// KeywordHeader は GetCategoryItems でカテゴリ内を絞り込む検索キーワードを指定するリクエストヘッダーです
+// 日本語を含む場合はURLエンコード（パーセントエンコーディング）して指定します
+const KeywordHeader = "X-Search-Keyword"
+
+// ExcludeKeywordsHeader は GetCategoryItems で結果から除外するキーワードを指定するリクエストヘッダーです
+// 複数指定する場合はカンマ区切りとし、各キーワードはURLエンコードして指定します
+const ExcludeKeywordsHeader = "X-Exclude-Keywords"
+
+// SortHeader は GetCategoryItems の並び順を指定するリクエストヘッダーです
+// "ending_soon" を指定すると終了時間の近い順に並べ、終了済みの商品を除外します
+const SortHeader = "X-Sort"
+
+// ClientSortHeader は GetCategoryItems で取得後に商品を並べ替えるキーを指定するリクエストヘッダーです
+// "price_asc", "price_desc", "bid_count_asc", "bid_count_desc", "end_time_asc" のいずれかを指定します
+// 並べ替えは取得したページ内でのみ行われ、カテゴリ全体での並び順にはなりません
+const ClientSortHeader = "X-Client-Sort"
+
+// FreeShippingOnlyHeader は GetCategoryItems で送料無料の商品のみに絞り込むリクエストヘッダーです
+// "true" または "1" を指定すると有効になります
+const FreeShippingOnlyHeader = "X-Free-Shipping-Only"
+
+// NewlyListedWithinHeader は GetCategoryItems で新着商品に絞り込む期間を指定するリクエストヘッダーです
+// "24h" のように Go の time.ParseDuration 形式で指定します。24時間を超える期間は指定できません
+const NewlyListedWithinHeader = "X-Newly-Listed-Within"
+
+// MinSellerRatingHeader は GetCategoryItems で出品者の良い評価の割合（0〜100）の下限を指定するリクエストヘッダーです
+// 指定すると商品ごとに詳細ページを取得して判定するため、応答が大幅に遅くなります
+const MinSellerRatingHeader = "X-Min-Seller-Rating"
+
+// SellerTypeHeader は GetCategoryItems で出品者の種別を絞り込むリクエストヘッダーです
+// "any", "store", "individual" のいずれかを指定します。指定しない場合は絞り込みません
+const SellerTypeHeader = "X-Seller-Type"
+
+// MaxImmediatePriceRatioHeader は GetCategoryItems で即決価格に対する現在価格の割合（0〜1）の上限を指定するリクエストヘッダーです
+// 例えば "0.5" を指定すると、現在価格が即決価格の半額未満の商品のみを返します。取得したページ内でのみ絞り込みます
+const MaxImmediatePriceRatioHeader = "X-Max-Immediate-Price-Ratio"
+
+// NoResultsHeader は GetCategoryItems のレスポンスヘッダーで、取得元が「該当する商品がありません」と表示したことを示します
+// 商品が0件でこのヘッダーがない場合は、ページ構造の変化などで商品を抽出できなかった可能性があります
+const NoResultsHeader = "X-No-Results"
+
+// PartialResultsHeader は GetCategoryItems のレスポンスヘッダーで、一部の商品を判定できずに除外したことを示します
+// 例えば MinSellerRatingHeader の絞り込みで、出品者の評価を取得できなかった商品があった場合に付きます
+const PartialResultsHeader = "X-Partial-Results"
+
+// FailedCategoriesHeader は GetCategoryItems で複数のカテゴリIDを指定した場合のレスポンスヘッダーで、
+// 取得に失敗して結果に含まれていないカテゴリIDをカンマ区切りで示します
+const FailedCategoriesHeader = "X-Failed-Categories"
+
 // CategoryGetter はカテゴリ商品取得ユースケースの最小インターフェースです。
 type CategoryGetter interface {
-	GetCategoryItems(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error)
+	GetCategoryItems(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error)
+	GetMultiCategoryItems(ctx context.Context, categoryIDs []string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error)
 }
 
 // AuctionHandler はgRPC/Connectのハンドラー実装です
@@ -41,9 +105,22 @@ func (h *AuctionHandler) GetAuction(
 	req *connect.Request[yahoo_auctionv1.GetAuctionRequest],
 ) (*connect.Response[yahoo_auctionv1.GetAuctionResponse], error) {
 	// ユースケースを呼び出して商品情報を取得
-	item, err := h.uc.GetAuction(ctx, req.Msg.AuctionId)
+	// フィールド指定がある場合は、不要な任意フィールドの抽出を省略します
+	var (
+		item *model.Item
+		err  error
+	)
+	if header := req.Header().Get(FieldsHeader); header != "" {
+		fields, parseErr := parseItemFields(header)
+		if parseErr != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, parseErr)
+		}
+		item, err = h.uc.GetAuctionWithFields(ctx, req.Msg.AuctionId, fields)
+	} else {
+		item, err = h.uc.GetAuction(ctx, req.Msg.AuctionId)
+	}
 	if err != nil {
-		return nil, connect.NewError(connect.CodeNotFound, err)
+		return nil, connect.NewError(errorCode(err, connect.CodeNotFound), err)
 	}
 
 	// ドメインモデルをprotobufのレスポンスに変換
@@ -86,10 +163,21 @@ func (h *AuctionHandler) GetCategoryItems(
 	ctx context.Context,
 	req *connect.Request[yahoo_auctionv1.GetCategoryItemsRequest],
 ) (*connect.Response[yahoo_auctionv1.GetCategoryItemsResponse], error) {
+	opts, err := categorySearchOptionsFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	// ユースケースを呼び出して一覧を取得
-	pageResult, err := h.catUC.GetCategoryItems(ctx, req.Msg.CategoryId, req.Msg.Page)
+	// category_id にカンマ区切りで複数指定された場合は、各カテゴリの結果を統合して返します
+	var pageResult *model.CategoryItemsPage
+	if categoryIDs := splitCategoryIDs(req.Msg.CategoryId); len(categoryIDs) > 1 {
+		pageResult, err = h.catUC.GetMultiCategoryItems(ctx, categoryIDs, req.Msg.Page, opts)
+	} else {
+		pageResult, err = h.catUC.GetCategoryItems(ctx, req.Msg.CategoryId, req.Msg.Page, opts)
+	}
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connect.NewError(errorCode(err, connect.CodeInternal), err)
 	}
 
 	// protoへの変換
@@ -105,10 +193,165 @@ func (h *AuctionHandler) GetCategoryItems(
 		})
 	}
 
-	resp := &yahoo_auctionv1.GetCategoryItemsResponse{
+	resp := connect.NewResponse(&yahoo_auctionv1.GetCategoryItemsResponse{
 		Items:      items,
 		TotalCount: pageResult.TotalCount,
+	})
+	if pageResult.NoResults {
+		resp.Header().Set(NoResultsHeader, "true")
+	}
+	if pageResult.Partial {
+		resp.Header().Set(PartialResultsHeader, "true")
+	}
+	if len(pageResult.FailedCategoryIDs) > 0 {
+		resp.Header().Set(FailedCategoriesHeader, strings.Join(pageResult.FailedCategoryIDs, ","))
 	}
 
-	return connect.NewResponse(resp), nil
+	return resp, nil
+}
+
+// errorCode はユースケースのエラーをConnectのエラーコードに変換します
+// 既知のエラーに該当しない場合は fallback を返します
+func errorCode(err error, fallback connect.Code) connect.Code {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		return connect.CodeInvalidArgument
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		return connect.CodePermissionDenied
+	case errors.Is(err, usecase.ErrTruncated):
+		return connect.CodeDeadlineExceeded
+	case errors.Is(err, repository.ErrNotFound):
+		return connect.CodeNotFound
+	case errors.Is(err, repository.ErrRateLimited):
+		// 対象が存在しないわけではないため、NotFound とは区別して再試行できることを示す
+		return connect.CodeResourceExhausted
+	case errors.Is(err, repository.ErrServiceUnavailable), errors.Is(err, repository.ErrChallengeRequired),
+		errors.Is(err, repository.ErrUnexpectedStatus), errors.Is(err, repository.ErrFetchFailed):
+		return connect.CodeUnavailable
+	default:
+		return fallback
+	}
+}
+
+// parseItemFields はカンマ区切りのフィールド名を model.ItemFields に変換します
+func parseItemFields(s string) (model.ItemFields, error) {
+	fields := model.ItemFieldsNone
+	for _, p := range strings.Split(s, ",") {
+		switch name := strings.TrimSpace(p); name {
+		case "":
+			// 空要素は無視
+		case "description":
+			fields |= model.ItemFieldDescription
+		case "images":
+			fields |= model.ItemFieldImages
+		case "seller":
+			fields |= model.ItemFieldSeller
+		case "related_items":
+			fields |= model.ItemFieldRelatedItems
+		case "questions":
+			fields |= model.ItemFieldQuestions
+		default:
+			return 0, fmt.Errorf("unknown field %q", name)
+		}
+	}
+	return fields, nil
+}
+
+// categorySearchOptionsFromHeader はリクエストヘッダーからカテゴリ検索条件を組み立てます
+func categorySearchOptionsFromHeader(header http.Header) (model.CategorySearchOptions, error) {
+	var opts model.CategorySearchOptions
+
+	if v := header.Get(KeywordHeader); v != "" {
+		keyword, err := url.QueryUnescape(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s header: %w", KeywordHeader, err)
+		}
+		opts.Keyword = keyword
+	}
+
+	if v := header.Get(ExcludeKeywordsHeader); v != "" {
+		for _, p := range strings.Split(v, ",") {
+			keyword, err := url.QueryUnescape(p)
+			if err != nil {
+				return opts, fmt.Errorf("invalid %s header: %w", ExcludeKeywordsHeader, err)
+			}
+			opts.ExcludeKeywords = append(opts.ExcludeKeywords, keyword)
+		}
+	}
+
+	if v := header.Get(FreeShippingOnlyHeader); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s header: %w", FreeShippingOnlyHeader, err)
+		}
+		opts.FreeShippingOnly = b
+	}
+
+	if v := header.Get(NewlyListedWithinHeader); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s header: %w", NewlyListedWithinHeader, err)
+		}
+		opts.NewlyListedWithin = d
+	}
+
+	if v := header.Get(MinSellerRatingHeader); v != "" {
+		pct, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s header: %w", MinSellerRatingHeader, err)
+		}
+		opts.MinSellerRatingPercentage = pct
+	}
+
+	if v := header.Get(MaxImmediatePriceRatioHeader); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s header: %w", MaxImmediatePriceRatioHeader, err)
+		}
+		opts.MaxImmediatePriceRatio = ratio
+	}
+
+	if v := header.Get(ClientSortHeader); v != "" {
+		key, ok := model.ParseSortKey(v)
+		if !ok {
+			return opts, fmt.Errorf("invalid %s header: unknown sort key %q", ClientSortHeader, v)
+		}
+		opts.SortBy = key
+	}
+
+	if v := header.Get(SellerTypeHeader); v != "" {
+		t, ok := model.ParseSellerType(v)
+		if !ok {
+			return opts, fmt.Errorf("invalid %s header: unknown seller type %q", SellerTypeHeader, v)
+		}
+		opts.SellerType = t
+	}
+
+	switch v := header.Get(SortHeader); v {
+	case "":
+		// 指定なし（新着順）
+	case "ending_soon":
+		opts.EndingSoon = true
+	default:
+		return opts, fmt.Errorf("invalid %s header: unknown sort %q", SortHeader, v)
+	}
+
+	return opts, nil
+}
+
+// splitCategoryIDs はカンマ区切りのカテゴリID文字列を分割します
+// 前後の空白を除去し、空要素と重複は取り除きます
+func splitCategoryIDs(s string) []string {
+	parts := strings.Split(s, ",")
+	ids := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, p := range parts {
+		id := strings.TrimSpace(p)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
 }
@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
@@ -10,23 +12,50 @@ import (
 	yahoo_auctionv1 "github.com/jo3qma/protobuf/gen/go/yahoo_auction/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
 )
 
 type fakeAuctionGetter struct {
 	item *model.Item
 	err  error
+
+	// gotFields は GetAuctionWithFields に渡されたフィールドを記録します
+	gotFields *model.ItemFields
 }
 
 func (f fakeAuctionGetter) GetAuction(ctx context.Context, auctionID string) (*model.Item, error) {
 	return f.item, f.err
 }
 
+func (f fakeAuctionGetter) GetAuctionWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	if f.gotFields != nil {
+		*f.gotFields = fields
+	}
+	return f.item, f.err
+}
+
 type fakeCategoryGetter struct {
 	page *model.CategoryItemsPage
 	err  error
+
+	// gotCategoryIDs は GetMultiCategoryItems に渡されたカテゴリIDを記録します
+	gotCategoryIDs *[]string
+	// gotOpts は GetCategoryItems に渡された検索条件を記録します
+	gotOpts *model.CategorySearchOptions
+}
+
+func (f fakeCategoryGetter) GetCategoryItems(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if f.gotOpts != nil {
+		*f.gotOpts = opts
+	}
+	return f.page, f.err
 }
 
-func (f fakeCategoryGetter) GetCategoryItems(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error) {
+func (f fakeCategoryGetter) GetMultiCategoryItems(ctx context.Context, categoryIDs []string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if f.gotCategoryIDs != nil {
+		*f.gotCategoryIDs = categoryIDs
+	}
 	return f.page, f.err
 }
 
@@ -139,6 +168,110 @@ func TestAuctionHandler_GetAuction_returnsNotFoundOnUsecaseError(t *testing.T) {
 	}
 }
 
+func TestAuctionHandler_GetAuction_passesFieldsHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.ItemFields
+	h := NewAuctionHandler(fakeAuctionGetter{item: &model.Item{AuctionID: "x1"}, gotFields: &got}, nil)
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetAuctionRequest{AuctionId: "x1"})
+	req.Header().Set(FieldsHeader, "images")
+	if _, err := h.GetAuction(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != model.ItemFieldImages {
+		t.Fatalf("fields got %v, want %v", got, model.ItemFieldImages)
+	}
+}
+
+func TestAuctionHandler_GetAuction_returnsInvalidArgumentOnUnknownField(t *testing.T) {
+	t.Parallel()
+
+	h := NewAuctionHandler(fakeAuctionGetter{item: &model.Item{}}, nil)
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetAuctionRequest{AuctionId: "x1"})
+	req.Header().Set(FieldsHeader, "description,bids")
+	_, err := h.GetAuction(context.Background(), req)
+
+	var ce *connect.Error
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected *connect.Error, got %T: %v", err, err)
+	}
+	if ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("code got %v, want %v", ce.Code(), connect.CodeInvalidArgument)
+	}
+}
+
+func TestAuctionHandler_returnsUnavailableDuringMaintenance(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("under maintenance: %w", repository.ErrServiceUnavailable)
+	h := NewAuctionHandler(fakeAuctionGetter{err: err}, fakeCategoryGetter{err: err})
+
+	_, auctionErr := h.GetAuction(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetAuctionRequest{AuctionId: "x1"}))
+	_, categoryErr := h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "1"}))
+
+	for _, got := range []error{auctionErr, categoryErr} {
+		var ce *connect.Error
+		if !errors.As(got, &ce) {
+			t.Fatalf("expected *connect.Error, got %T: %v", got, got)
+		}
+		if ce.Code() != connect.CodeUnavailable {
+			t.Fatalf("code got %v, want %v", ce.Code(), connect.CodeUnavailable)
+		}
+	}
+}
+
+func TestAuctionHandler_returnsUnavailableOnChallenge(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("challenge page returned: %w", repository.ErrChallengeRequired)
+	h := NewAuctionHandler(fakeAuctionGetter{err: err}, fakeCategoryGetter{err: err})
+
+	_, got := h.GetAuction(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetAuctionRequest{AuctionId: "x1"}))
+	var ce *connect.Error
+	if !errors.As(got, &ce) {
+		t.Fatalf("expected *connect.Error, got %T: %v", got, got)
+	}
+	if ce.Code() != connect.CodeUnavailable {
+		t.Fatalf("code got %v, want %v", ce.Code(), connect.CodeUnavailable)
+	}
+}
+
+// TestAuctionHandler_GetAuction_mapsFetchErrors は取得元の一時的な失敗を NotFound として返さないことを確認します
+// NotFound を「オークションが削除された」と扱うクライアントが、制限中に出品中のオークションを破棄しないようにするためです
+func TestAuctionHandler_GetAuction_mapsFetchErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{name: "page gone", err: fmt.Errorf("status 404: %w", repository.ErrNotFound), want: connect.CodeNotFound},
+		{name: "rate limited", err: fmt.Errorf("status 429: %w", repository.ErrRateLimited), want: connect.CodeResourceExhausted},
+		{name: "server error", err: fmt.Errorf("status 502: %w", repository.ErrUnexpectedStatus), want: connect.CodeUnavailable},
+		{name: "transport error", err: fmt.Errorf("%w: connection reset", repository.ErrFetchFailed), want: connect.CodeUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewAuctionHandler(fakeAuctionGetter{err: tc.err}, nil)
+			_, err := h.GetAuction(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetAuctionRequest{AuctionId: "x1"}))
+
+			var ce *connect.Error
+			if !errors.As(err, &ce) {
+				t.Fatalf("expected *connect.Error, got %T: %v", err, err)
+			}
+			if ce.Code() != tc.want {
+				t.Fatalf("code got %v, want %v", ce.Code(), tc.want)
+			}
+		})
+	}
+}
+
 func TestAuctionHandler_GetCategoryItems_mapsDomainToProto(t *testing.T) {
 	t.Parallel()
 
@@ -233,3 +366,296 @@ func TestAuctionHandler_GetCategoryItems_returnsErrorOnUsecaseError(t *testing.T
 		t.Fatalf("code got %v, want %v", ce.Code(), connect.CodeInternal)
 	}
 }
+
+func TestAuctionHandler_GetCategoryItems_splitsCommaSeparatedIDs(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	h := NewAuctionHandler(nil, fakeCategoryGetter{
+		page:           &model.CategoryItemsPage{TotalCount: 3},
+		gotCategoryIDs: &got,
+	})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{
+		CategoryId: "2084261685, 2084261686,,2084261685",
+	})
+
+	resp, err := h.GetCategoryItems(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Msg.TotalCount != 3 {
+		t.Fatalf("TotalCount got %d, want %d", resp.Msg.TotalCount, 3)
+	}
+
+	want := []string{"2084261685", "2084261686"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("category IDs got %#v, want %#v", got, want)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_returnsInvalidArgumentOnValidationError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("%w: category id must be numeric", usecase.ErrInvalidArgument)
+	h := NewAuctionHandler(nil, fakeCategoryGetter{err: err})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "camera"})
+	_, err = h.GetCategoryItems(context.Background(), req)
+
+	var ce *connect.Error
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected *connect.Error, got %T: %v", err, err)
+	}
+	if ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("code got %v, want %v", ce.Code(), connect.CodeInvalidArgument)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_noResultsHeader(t *testing.T) {
+	t.Parallel()
+
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{NoResults: true}})
+	resp, err := h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header().Get(NoResultsHeader); got != "true" {
+		t.Errorf("%s got %q, want %q", NoResultsHeader, got, "true")
+	}
+
+	h = NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}})
+	resp, err = h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header().Get(NoResultsHeader); got != "" {
+		t.Errorf("%s got %q, want empty", NoResultsHeader, got)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_partialResultsHeader(t *testing.T) {
+	t.Parallel()
+
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{Partial: true}})
+	resp, err := h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header().Get(PartialResultsHeader); got != "true" {
+		t.Errorf("%s got %q, want %q", PartialResultsHeader, got, "true")
+	}
+
+	h = NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}})
+	resp, err = h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header().Get(PartialResultsHeader); got != "" {
+		t.Errorf("%s got %q, want empty", PartialResultsHeader, got)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_failedCategoriesHeader(t *testing.T) {
+	t.Parallel()
+
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{Partial: true, FailedCategoryIDs: []string{"2084005", "2084261685"}}})
+	resp, err := h.GetCategoryItems(context.Background(), connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "23336,2084005,2084261685"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := resp.Header().Get(FailedCategoriesHeader), "2084005,2084261685"; got != want {
+		t.Errorf("%s got %q, want %q", FailedCategoriesHeader, got, want)
+	}
+	if got := resp.Header().Get(PartialResultsHeader); got != "true" {
+		t.Errorf("%s got %q, want %q", PartialResultsHeader, got, "true")
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_passesKeywordHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(KeywordHeader, url.QueryEscape("ニコン F3"))
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Keyword != "ニコン F3" {
+		t.Fatalf("Keyword got %q, want %q", got.Keyword, "ニコン F3")
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_passesExcludeKeywordsHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(ExcludeKeywordsHeader, url.QueryEscape("ジャンク")+","+url.QueryEscape("部品取り"))
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"ジャンク", "部品取り"}
+	if len(got.ExcludeKeywords) != len(want) || got.ExcludeKeywords[0] != want[0] || got.ExcludeKeywords[1] != want[1] {
+		t.Fatalf("ExcludeKeywords got %#v, want %#v", got.ExcludeKeywords, want)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_sortHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(SortHeader, "ending_soon")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.EndingSoon {
+		t.Fatalf("EndingSoon got false, want true")
+	}
+
+	req.Header().Set(SortHeader, "price")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_freeShippingOnlyHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(FreeShippingOnlyHeader, "true")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.FreeShippingOnly {
+		t.Fatalf("FreeShippingOnly got false, want true")
+	}
+
+	req.Header().Set(FreeShippingOnlyHeader, "yes please")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_newlyListedWithinHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(NewlyListedWithinHeader, "24h")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.NewlyListedWithin != 24*time.Hour {
+		t.Fatalf("NewlyListedWithin got %v, want %v", got.NewlyListedWithin, 24*time.Hour)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_minSellerRatingHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(MinSellerRatingHeader, "98.5")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.MinSellerRatingPercentage != 98.5 {
+		t.Fatalf("MinSellerRatingPercentage got %v, want 98.5", got.MinSellerRatingPercentage)
+	}
+
+	req.Header().Set(MinSellerRatingHeader, "high")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_maxImmediatePriceRatioHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(MaxImmediatePriceRatioHeader, "0.5")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.MaxImmediatePriceRatio != 0.5 {
+		t.Fatalf("MaxImmediatePriceRatio got %v, want 0.5", got.MaxImmediatePriceRatio)
+	}
+
+	req.Header().Set(MaxImmediatePriceRatioHeader, "half")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_clientSortHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(ClientSortHeader, "bid_count_asc")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.SortBy != model.SortKeyBidCountAsc {
+		t.Fatalf("SortBy got %v, want %v", got.SortBy, model.SortKeyBidCountAsc)
+	}
+
+	req.Header().Set(ClientSortHeader, "popularity")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuctionHandler_GetCategoryItems_sellerTypeHeader(t *testing.T) {
+	t.Parallel()
+
+	var got model.CategorySearchOptions
+	h := NewAuctionHandler(nil, fakeCategoryGetter{page: &model.CategoryItemsPage{}, gotOpts: &got})
+
+	req := connect.NewRequest(&yahoo_auctionv1.GetCategoryItemsRequest{CategoryId: "2084261685"})
+	req.Header().Set(SellerTypeHeader, "store")
+	if _, err := h.GetCategoryItems(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.SellerType != model.SellerTypeStore {
+		t.Fatalf("SellerType got %v, want %v", got.SellerType, model.SellerTypeStore)
+	}
+
+	req.Header().Set(SellerTypeHeader, "corporate")
+	_, err := h.GetCategoryItems(context.Background(), req)
+	var ce *connect.Error
+	if !errors.As(err, &ce) || ce.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("got error %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// AuctionSummariesBatchGetter は複数のオークション概要を取得するユースケースの最小インターフェースです。
+type AuctionSummariesBatchGetter interface {
+	BatchGetAuctionSummaries(ctx context.Context, auctionIDs []string) ([]model.Result[*model.AuctionSummary], error)
+}
+
+// BatchAuctionSummaryPattern は BatchAuctionSummaryHandler を登録するルーティングパターンです
+const BatchAuctionSummaryPattern = "GET /v1/auctions/summaries"
+
+// BatchAuctionSummaryHandler は複数のオークション概要をまとめてJSONで返すHTTPハンドラーです
+// 一部のIDの取得に失敗しても、成功した概要と失敗したIDごとのエラーを 200 で返します
+type BatchAuctionSummaryHandler struct {
+	uc AuctionSummariesBatchGetter
+}
+
+// NewBatchAuctionSummaryHandler は新しいBatchAuctionSummaryHandlerインスタンスを作成します
+func NewBatchAuctionSummaryHandler(uc AuctionSummariesBatchGetter) *BatchAuctionSummaryHandler {
+	return &BatchAuctionSummaryHandler{
+		uc: uc,
+	}
+}
+
+// batchAuctionSummaryResponse はJSONレスポンスの形式です
+type batchAuctionSummaryResponse struct {
+	Summaries []auctionSummaryResponse `json:"summaries"`
+	Errors    []batchError             `json:"errors"`
+}
+
+// batchError は取得に失敗したIDとその理由です
+type batchError struct {
+	AuctionID string `json:"auction_id"`
+	Error     string `json:"error"`
+}
+
+// ServeHTTP はクエリの ids（カンマ区切り）のオークション概要を取得して返します
+func (h *BatchAuctionSummaryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	results, err := h.uc.BatchGetAuctionSummaries(r.Context(), ids)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	resp := batchAuctionSummaryResponse{
+		Summaries: make([]auctionSummaryResponse, 0, len(results)),
+		Errors:    make([]batchError, 0),
+	}
+	for _, res := range results {
+		if !res.OK() {
+			resp.Errors = append(resp.Errors, batchError{AuctionID: res.ID, Error: res.Err.Error()})
+			continue
+		}
+		resp.Summaries = append(resp.Summaries, newAuctionSummaryResponse(res.Value))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("warning: failed to write batch summary response: %v", err)
+	}
+}
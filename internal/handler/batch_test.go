@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/memory"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+func TestBatchAuctionSummaryHandler(t *testing.T) {
+	t.Parallel()
+
+	uc := usecase.NewAuctionUsecase(memory.NewItemRepository(
+		&model.Item{AuctionID: "x1", Title: "one", CurrentPrice: 100, Status: model.StatusActive},
+	))
+
+	cases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "partial success",
+			query:      "?ids=x1,x2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"summaries":[{"auction_id":"x1","title":"one","current_price":100,"status":"active"}],` +
+				`"errors":[{"auction_id":"x2","error":"auction x2: not found"}]}` + "\n",
+		},
+		{
+			name:       "all succeed",
+			query:      "?ids=x1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"summaries":[{"auction_id":"x1","title":"one","current_price":100,"status":"active"}],"errors":[]}` + "\n",
+		},
+		{
+			name:       "no ids",
+			query:      "?ids=",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(BatchAuctionSummaryPattern, NewBatchAuctionSummaryHandler(uc))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/summaries"+tc.query, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("body got %s, want %s", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// CategoryItemCountGetter はカテゴリの商品数取得ユースケースの最小インターフェースです。
+type CategoryItemCountGetter interface {
+	GetCategoryItemCount(ctx context.Context, categoryID string) (int64, error)
+}
+
+// CategoryItemCountPattern は CategoryItemCountHandler を登録するルーティングパターンです
+const CategoryItemCountPattern = "GET /v1/categories/{categoryID}/count"
+
+// CategoryItemCountHandler はカテゴリの商品の総数のみをJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれない軽量APIのため、net/http のハンドラーとして提供します
+type CategoryItemCountHandler struct {
+	uc CategoryItemCountGetter
+}
+
+// NewCategoryItemCountHandler は新しいCategoryItemCountHandlerインスタンスを作成します
+func NewCategoryItemCountHandler(uc CategoryItemCountGetter) *CategoryItemCountHandler {
+	return &CategoryItemCountHandler{
+		uc: uc,
+	}
+}
+
+// categoryItemCountResponse はJSONレスポンスの形式です
+type categoryItemCountResponse struct {
+	CategoryID string `json:"category_id"`
+	TotalCount int64  `json:"total_count"`
+}
+
+// ServeHTTP はパスの categoryID から商品の総数を取得して返します
+func (h *CategoryItemCountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	categoryID := r.PathValue("categoryID")
+
+	count, err := h.uc.GetCategoryItemCount(r.Context(), categoryID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(categoryItemCountResponse{CategoryID: categoryID, TotalCount: count}); err != nil {
+		log.Printf("warning: failed to write category count response: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+type fakeCategoryItemCountGetter struct {
+	count int64
+	err   error
+}
+
+func (f fakeCategoryItemCountGetter) GetCategoryItemCount(ctx context.Context, categoryID string) (int64, error) {
+	return f.count, f.err
+}
+
+func TestCategoryItemCountHandler(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		getter     fakeCategoryItemCountGetter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ok",
+			getter:     fakeCategoryItemCountGetter{count: 1234},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"category_id\":\"2084005\",\"total_count\":1234}\n",
+		},
+		{
+			name:       "invalid category",
+			getter:     fakeCategoryItemCountGetter{err: fmt.Errorf("%w: category id must be numeric", usecase.ErrInvalidArgument)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "denied category",
+			getter:     fakeCategoryItemCountGetter{err: fmt.Errorf("%w: category 2084005 is not allowed", usecase.ErrPermissionDenied)},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(CategoryItemCountPattern, NewCategoryItemCountHandler(tc.getter))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/categories/2084005/count", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("body got %q, want %q", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+
+	"connectrpc.com/connect"
+)
+
+// errShuttingDown はシャットダウン中に受け付けたリクエストに返すエラーです
+var errShuttingDown = errors.New("server is shutting down")
+
+// Drainer は処理中のリクエストを追跡し、シャットダウン時に完了を待つミドルウェアです
+// Drain の呼び出し後に届いたリクエストは Unavailable（HTTP 503）で拒否します
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight sync.WaitGroup
+
+	errWriter *connect.ErrorWriter
+}
+
+// NewDrainer は新しいDrainerインスタンスを作成します
+func NewDrainer() *Drainer {
+	return &Drainer{
+		errWriter: connect.NewErrorWriter(),
+	}
+}
+
+// Wrap は next を処理中のリクエストとして追跡するハンドラーを返します
+func (d *Drainer) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// draining の確認と Add を同じロック内で行い、Drain の Wait 開始後に Add されないようにする
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			d.reject(w, r)
+			return
+		}
+		d.inFlight.Add(1)
+		d.mu.Unlock()
+		defer d.inFlight.Done()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Drain は新しいリクエストの受け付けを止め、処理中のリクエストの完了を待ちます
+// ctx が先に終了した場合はそのエラーを返します
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reject はシャットダウン中のため Unavailable を返します
+// Connect/gRPC のリクエストにはプロトコルに沿ったエラーを、それ以外には HTTP 503 を返します
+func (d *Drainer) reject(w http.ResponseWriter, r *http.Request) {
+	if d.errWriter.IsSupported(r) {
+		if err := d.errWriter.Write(w, r, connect.NewError(connect.CodeUnavailable, errShuttingDown)); err != nil {
+			log.Printf("warning: failed to write shutdown error: %v", err)
+		}
+		return
+	}
+	http.Error(w, errShuttingDown.Error(), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// CategoryPageStreamer はカテゴリの商品一覧をページごとに取得するユースケースの最小インターフェースです。
+type CategoryPageStreamer interface {
+	EachCategoryPage(ctx context.Context, categoryID string, fromPage, toPage int64, opts model.CategorySearchOptions, fn func(page int64, p *model.CategoryItemsPage) error) error
+}
+
+// CategoryCSVPattern は CategoryCSVHandler を登録するルーティングパターンです
+const CategoryCSVPattern = "GET /v1/categories/{categoryID}/items.csv"
+
+// auctionPageURL は商品詳細ページのURLの書式です
+const auctionPageURL = "https://page.auctions.yahoo.co.jp/jp/auction/%s"
+
+// csvHeader はCSVの見出し行です
+var csvHeader = []string{"auction_id", "title", "current_price", "immediate_price", "bid_count", "url"}
+
+// CategoryCSVHandler はカテゴリの商品一覧をCSVでダウンロードさせるHTTPハンドラーです
+// ページを取得するたびに行を書き出すため、全ページの取得を待たずにダウンロードが始まります
+type CategoryCSVHandler struct {
+	uc       CategoryPageStreamer
+	maxPages int64
+}
+
+// NewCategoryCSVHandler は新しいCategoryCSVHandlerインスタンスを作成します
+// maxPages は1回の出力で取得するページ数の上限です
+func NewCategoryCSVHandler(uc CategoryPageStreamer, maxPages int64) *CategoryCSVHandler {
+	return &CategoryCSVHandler{
+		uc:       uc,
+		maxPages: maxPages,
+	}
+}
+
+// ServeHTTP はパスの categoryID の商品一覧をCSVで返します
+// クエリ pages で取得するページ数を指定できます（maxPages が上限。省略時は maxPages）
+func (h *CategoryCSVHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	categoryID := r.PathValue("categoryID")
+
+	pages := h.maxPages
+	if v := r.URL.Query().Get("pages"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			http.Error(w, "pages must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		pages = min(n, h.maxPages)
+	}
+
+	opts, err := categorySearchOptionsFromHeader(r.Header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cw := csv.NewWriter(w)
+	rc := http.NewResponseController(w)
+	started := false
+
+	// ページ間の待機を含めると全ページの出力はサーバーの WriteTimeout より長くかかるため、書き込み期限を解除する
+	// 出力全体の時間は EachCategoryPage の時間の上限（WithAggregateTimeout）とリクエストの ctx で制限される
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("warning: failed to clear write deadline for category csv export: %v", err)
+	}
+
+	err = h.uc.EachCategoryPage(r.Context(), categoryID, 0, pages-1, opts, func(_ int64, p *model.CategoryItemsPage) error {
+		// 最初のページの取得に成功してからヘッダーを書き出し、それまでのエラーはステータスコードで返す
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="category_%s.csv"`, categoryID))
+			if err := cw.Write(csvHeader); err != nil {
+				return err
+			}
+		}
+		for _, item := range p.Items {
+			if err := cw.Write(csvRecord(item)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			http.Error(w, err.Error(), httpStatus(err, http.StatusInternalServerError))
+			return
+		}
+		// 書き出し開始後はステータスコードを変更できないため、ログに残して打ち切る
+		log.Printf("warning: category csv export for %s stopped: %v", categoryID, err)
+	}
+}
+
+// csvRecord は商品をCSVの1行に変換します
+func csvRecord(item *model.CategoryItem) []string {
+	return []string{
+		item.AuctionID,
+		item.Title,
+		strconv.FormatInt(item.CurrentPrice, 10),
+		strconv.FormatInt(item.ImmediatePrice, 10),
+		strconv.FormatInt(item.BidCount, 10),
+		fmt.Sprintf(auctionPageURL, item.AuctionID),
+	}
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+// fakePageStreamer は pages を順に fn に渡すフェイクです。toPage を超えるページは渡しません
+type fakePageStreamer struct {
+	pages    []*model.CategoryItemsPage
+	err      error // 全ページを渡した後に返すエラー
+	startErr error // fn を呼び出す前に返すエラー
+	gotTo    *int64
+}
+
+func (f fakePageStreamer) EachCategoryPage(ctx context.Context, categoryID string, fromPage, toPage int64, opts model.CategorySearchOptions, fn func(page int64, p *model.CategoryItemsPage) error) error {
+	if f.gotTo != nil {
+		*f.gotTo = toPage
+	}
+	if f.startErr != nil {
+		return f.startErr
+	}
+	for i, p := range f.pages {
+		if int64(i) > toPage {
+			break
+		}
+		if err := fn(int64(i), p); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func TestCategoryCSVHandler_writesRows(t *testing.T) {
+	t.Parallel()
+
+	var gotTo int64
+	mux := http.NewServeMux()
+	mux.Handle(CategoryCSVPattern, NewCategoryCSVHandler(fakePageStreamer{
+		pages: []*model.CategoryItemsPage{
+			{Items: []*model.CategoryItem{{AuctionID: "a1", Title: "camera, lens", CurrentPrice: 1000, ImmediatePrice: 2000, BidCount: 3}}},
+			{Items: []*model.CategoryItem{{AuctionID: "a2", Title: "body", CurrentPrice: 500}}},
+		},
+		gotTo: &gotTo,
+	}, 5))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/categories/2084005/items.csv", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type got %q", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="category_2084005.csv"` {
+		t.Errorf("Content-Disposition got %q", got)
+	}
+	if gotTo != 4 {
+		t.Errorf("toPage got %d, want 4", gotTo)
+	}
+
+	want := "auction_id,title,current_price,immediate_price,bid_count,url\n" +
+		"a1,\"camera, lens\",1000,2000,3,https://page.auctions.yahoo.co.jp/jp/auction/a1\n" +
+		"a2,body,500,0,0,https://page.auctions.yahoo.co.jp/jp/auction/a2\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body got %q, want %q", got, want)
+	}
+}
+
+func TestCategoryCSVHandler_pagesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantTo     int64
+	}{
+		{name: "within max", query: "?pages=2", wantStatus: http.StatusOK, wantTo: 1},
+		{name: "capped by max", query: "?pages=100", wantStatus: http.StatusOK, wantTo: 4},
+		{name: "invalid", query: "?pages=0", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotTo int64
+			mux := http.NewServeMux()
+			mux.Handle(CategoryCSVPattern, NewCategoryCSVHandler(fakePageStreamer{
+				pages: []*model.CategoryItemsPage{{}},
+				gotTo: &gotTo,
+			}, 5))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/categories/2084005/items.csv"+tc.query, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK && gotTo != tc.wantTo {
+				t.Fatalf("toPage got %d, want %d", gotTo, tc.wantTo)
+			}
+		})
+	}
+}
+
+func TestCategoryCSVHandler_errorBeforeFirstPage(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(CategoryCSVPattern, NewCategoryCSVHandler(fakePageStreamer{
+		startErr: fmt.Errorf("invalid category: %w", usecase.ErrInvalidArgument),
+	}, 5))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/categories/abc/items.csv", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestCategoryCSVHandler_errorAfterFirstPageKeepsRows(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(CategoryCSVPattern, NewCategoryCSVHandler(fakePageStreamer{
+		pages: []*model.CategoryItemsPage{{Items: []*model.CategoryItem{{AuctionID: "a1"}}}},
+		err:   errors.New("fetch failed"),
+	}, 5))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/categories/2084005/items.csv", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+	}
+	want := "auction_id,title,current_price,immediate_price,bid_count,url\n" +
+		"a1,,0,0,0,https://page.auctions.yahoo.co.jp/jp/auction/a1\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body got %q, want %q", got, want)
+	}
+}
+
+// slowPageStreamer は各ページを渡す前に delay だけ待機するフェイクです（ページ間の待機を模擬します）
+type slowPageStreamer struct {
+	pages int
+	delay time.Duration
+}
+
+func (f slowPageStreamer) EachCategoryPage(ctx context.Context, categoryID string, fromPage, toPage int64, opts model.CategorySearchOptions, fn func(page int64, p *model.CategoryItemsPage) error) error {
+	for i := 0; i < f.pages; i++ {
+		time.Sleep(f.delay)
+		if err := fn(int64(i), &model.CategoryItemsPage{Items: []*model.CategoryItem{{AuctionID: fmt.Sprintf("a%d", i)}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCategoryCSVHandler_outlivesWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	const pages = 4
+	mux := http.NewServeMux()
+	mux.Handle(CategoryCSVPattern, NewCategoryCSVHandler(slowPageStreamer{pages: pages, delay: 60 * time.Millisecond}, pages))
+
+	// 出力全体（約240ms）よりも短い WriteTimeout のサーバーで実行する
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/v1/categories/2084005/items.csv")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if got := strings.Count(string(body), "\n"); got != pages+1 {
+		t.Errorf("lines got %d, want %d (header + %d rows): %q", got, pages+1, pages, body)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// AuctionQuestionsGetter は質問と回答の取得ユースケースの最小インターフェースです。
+type AuctionQuestionsGetter interface {
+	GetAuctionQuestions(ctx context.Context, auctionID string) ([]*model.QA, error)
+}
+
+// AuctionQuestionsPattern は AuctionQuestionsHandler を登録するルーティングパターンです
+const AuctionQuestionsPattern = "GET /v1/auctions/{auctionID}/questions"
+
+// AuctionQuestionsHandler は質問と回答の一覧をJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれないため、net/http のハンドラーとして提供します
+type AuctionQuestionsHandler struct {
+	uc AuctionQuestionsGetter
+}
+
+// NewAuctionQuestionsHandler は新しいAuctionQuestionsHandlerインスタンスを作成します
+func NewAuctionQuestionsHandler(uc AuctionQuestionsGetter) *AuctionQuestionsHandler {
+	return &AuctionQuestionsHandler{
+		uc: uc,
+	}
+}
+
+// auctionQuestionsResponse はJSONレスポンスの形式です
+type auctionQuestionsResponse struct {
+	Questions []question `json:"questions"`
+}
+
+// question は質問と回答1件のJSON表現です
+type question struct {
+	Question   string     `json:"question"`
+	Answer     string     `json:"answer"`
+	AskedAt    *time.Time `json:"asked_at,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+}
+
+// ServeHTTP はパスの auctionID から質問と回答を取得して返します
+func (h *AuctionQuestionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auctionID := r.PathValue("auctionID")
+	if auctionID == "" {
+		http.Error(w, "auction id is required", http.StatusBadRequest)
+		return
+	}
+
+	qas, err := h.uc.GetAuctionQuestions(r.Context(), auctionID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusNotFound))
+		return
+	}
+
+	resp := auctionQuestionsResponse{Questions: make([]question, 0, len(qas))}
+	for _, qa := range qas {
+		q := question{
+			Question: qa.Question,
+			Answer:   qa.Answer,
+		}
+		if !qa.AskedAt.IsZero() {
+			q.AskedAt = &qa.AskedAt
+		}
+		if !qa.AnsweredAt.IsZero() {
+			q.AnsweredAt = &qa.AnsweredAt
+		}
+		resp.Questions = append(resp.Questions, q)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("warning: failed to write questions response: %v", err)
+	}
+}
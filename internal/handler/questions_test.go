@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+type fakeQuestionsGetter struct {
+	questions []*model.QA
+	err       error
+}
+
+func (f fakeQuestionsGetter) GetAuctionQuestions(ctx context.Context, auctionID string) ([]*model.QA, error) {
+	return f.questions, f.err
+}
+
+func TestAuctionQuestionsHandler(t *testing.T) {
+	t.Parallel()
+
+	asked := time.Date(2025, 12, 28, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		getter   fakeQuestionsGetter
+		wantBody string
+	}{
+		{
+			name: "answered and unanswered",
+			getter: fakeQuestionsGetter{questions: []*model.QA{
+				{Question: "動作しますか？", Answer: "はい", AskedAt: asked, AnsweredAt: asked.Add(time.Hour)},
+				{Question: "箱はありますか？"},
+			}},
+			wantBody: `{"questions":[` +
+				`{"question":"動作しますか？","answer":"はい","asked_at":"2025-12-28T10:00:00Z","answered_at":"2025-12-28T11:00:00Z"},` +
+				`{"question":"箱はありますか？","answer":""}]}` + "\n",
+		},
+		{
+			name:     "no questions",
+			getter:   fakeQuestionsGetter{questions: []*model.QA{}},
+			wantBody: "{\"questions\":[]}\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(AuctionQuestionsPattern, NewAuctionQuestionsHandler(tc.getter))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/questions", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Body.String(); got != tc.wantBody {
+				t.Errorf("body got %s, want %s", got, tc.wantBody)
+			}
+		})
+	}
+}
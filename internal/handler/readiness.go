@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// ReadinessCheck はサーバーがリクエストを受け付けられる状態かを確認する関数です
+// 受け付けられない場合は理由を表すエラーを返します
+type ReadinessCheck func() error
+
+// ReadinessPattern は ReadinessHandler を登録するルーティングパターンです
+const ReadinessPattern = "GET /readyz"
+
+// ReadinessHandler はすべての ReadinessCheck が成功した場合に 200、いずれかが失敗した場合に 503 を返すHTTPハンドラーです
+// ロードバランサーやKubernetesの readinessProbe から利用します
+type ReadinessHandler struct {
+	checks []ReadinessCheck
+}
+
+// NewReadinessHandler は新しいReadinessHandlerインスタンスを作成します
+func NewReadinessHandler(checks ...ReadinessCheck) *ReadinessHandler {
+	return &ReadinessHandler{
+		checks: checks,
+	}
+}
+
+// readinessResponse はJSONレスポンスの形式です
+type readinessResponse struct {
+	Status string `json:"status"`
+}
+
+// ServeHTTP は登録されたチェックを順に実行し、結果を返します
+func (h *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var errs []error
+	for _, check := range h.checks {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(readinessResponse{Status: "ok"}); err != nil {
+		log.Printf("warning: failed to write readiness response: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	t.Parallel()
+
+	ok := func() error { return nil }
+	unhealthy := func() error { return errors.New("extraction success rate is too low") }
+
+	cases := []struct {
+		name       string
+		checks     []ReadinessCheck
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "no checks",
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"status\":\"ok\"}\n",
+		},
+		{
+			name:       "all checks pass",
+			checks:     []ReadinessCheck{ok, ok},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"status\":\"ok\"}\n",
+		},
+		{
+			name:       "one check fails",
+			checks:     []ReadinessCheck{ok, unhealthy},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "extraction success rate is too low",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(ReadinessPattern, NewReadinessHandler(tc.checks...))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Errorf("body got %q, want it to contain %q", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
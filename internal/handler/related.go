@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// RelatedItemsGetter は関連商品取得ユースケースの最小インターフェースです。
+type RelatedItemsGetter interface {
+	GetRelatedItems(ctx context.Context, auctionID string) ([]*model.CategoryItem, error)
+}
+
+// RelatedItemsPattern は RelatedItemsHandler を登録するルーティングパターンです
+const RelatedItemsPattern = "GET /v1/auctions/{auctionID}/related"
+
+// RelatedItemsHandler は関連商品の一覧をJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれないため、net/http のハンドラーとして提供します
+type RelatedItemsHandler struct {
+	uc RelatedItemsGetter
+}
+
+// NewRelatedItemsHandler は新しいRelatedItemsHandlerインスタンスを作成します
+func NewRelatedItemsHandler(uc RelatedItemsGetter) *RelatedItemsHandler {
+	return &RelatedItemsHandler{
+		uc: uc,
+	}
+}
+
+// relatedItemsResponse はJSONレスポンスの形式です
+type relatedItemsResponse struct {
+	Items []relatedItem `json:"items"`
+}
+
+// relatedItem は関連商品1件のJSON表現です
+// フィールド名は GetCategoryItems のJSON表現に揃えます
+type relatedItem struct {
+	AuctionID      string `json:"auction_id"`
+	Title          string `json:"title"`
+	CurrentPrice   int64  `json:"current_price"`
+	ImmediatePrice int64  `json:"immediate_price"`
+	Image          string `json:"image"`
+	BidCount       int64  `json:"bid_count"`
+}
+
+// ServeHTTP はパスの auctionID から関連商品を取得して返します
+func (h *RelatedItemsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auctionID := r.PathValue("auctionID")
+	if auctionID == "" {
+		http.Error(w, "auction id is required", http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.uc.GetRelatedItems(r.Context(), auctionID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusNotFound))
+		return
+	}
+
+	resp := relatedItemsResponse{Items: make([]relatedItem, 0, len(items))}
+	for _, item := range items {
+		resp.Items = append(resp.Items, relatedItem{
+			AuctionID:      item.AuctionID,
+			Title:          item.Title,
+			CurrentPrice:   item.CurrentPrice,
+			ImmediatePrice: item.ImmediatePrice,
+			Image:          item.Image,
+			BidCount:       item.BidCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("warning: failed to write related items response: %v", err)
+	}
+}
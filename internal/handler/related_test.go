@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+type fakeRelatedItemsGetter struct {
+	items []*model.CategoryItem
+	err   error
+}
+
+func (f fakeRelatedItemsGetter) GetRelatedItems(ctx context.Context, auctionID string) ([]*model.CategoryItem, error) {
+	return f.items, f.err
+}
+
+func TestRelatedItemsHandler_returnsJSON(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(RelatedItemsPattern, NewRelatedItemsHandler(fakeRelatedItemsGetter{items: []*model.CategoryItem{
+		{AuctionID: "r1", Title: "related", CurrentPrice: 500, BidCount: 2},
+	}}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/related", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got relatedItemsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].AuctionID != "r1" || got.Items[0].CurrentPrice != 500 {
+		t.Fatalf("Items got %+v", got.Items)
+	}
+}
+
+func TestRelatedItemsHandler_returnsEmptyArray(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(RelatedItemsPattern, NewRelatedItemsHandler(fakeRelatedItemsGetter{items: []*model.CategoryItem{}}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/related", nil))
+
+	if got := rec.Body.String(); got != "{\"items\":[]}\n" {
+		t.Fatalf("body got %q, want %q", got, "{\"items\":[]}\n")
+	}
+}
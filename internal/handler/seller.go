@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// SellerRatingGetter は出品者評価取得ユースケースの最小インターフェースです。
+type SellerRatingGetter interface {
+	GetSellerRating(ctx context.Context, sellerID string) (*model.SellerRating, error)
+}
+
+// SellerRatingPattern は SellerRatingHandler を登録するルーティングパターンです
+const SellerRatingPattern = "GET /v1/sellers/{sellerID}/rating"
+
+// SellerRatingHandler は出品者の評価の内訳をJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれないため、net/http のハンドラーとして提供します
+type SellerRatingHandler struct {
+	uc SellerRatingGetter
+}
+
+// NewSellerRatingHandler は新しいSellerRatingHandlerインスタンスを作成します
+func NewSellerRatingHandler(uc SellerRatingGetter) *SellerRatingHandler {
+	return &SellerRatingHandler{
+		uc: uc,
+	}
+}
+
+// ratingCountsResponse は評価の内訳のJSON表現です
+type ratingCountsResponse struct {
+	Good   int64 `json:"good"`
+	Normal int64 `json:"normal"`
+	Bad    int64 `json:"bad"`
+}
+
+// sellerRatingResponse はJSONレスポンスの形式です
+type sellerRatingResponse struct {
+	SellerID     string               `json:"seller_id"`
+	Last6Months  ratingCountsResponse `json:"last_6_months"`
+	Last12Months ratingCountsResponse `json:"last_12_months"`
+	Total        ratingCountsResponse `json:"total"`
+}
+
+// ServeHTTP はパスの sellerID から評価の内訳を取得して返します
+func (h *SellerRatingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rating, err := h.uc.GetSellerRating(r.Context(), r.PathValue("sellerID"))
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusNotFound))
+		return
+	}
+
+	resp := sellerRatingResponse{
+		SellerID:     rating.SellerID,
+		Last6Months:  ratingCountsResponse(rating.Last6Months),
+		Last12Months: ratingCountsResponse(rating.Last12Months),
+		Total:        ratingCountsResponse(rating.Total),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("warning: failed to write seller rating response: %v", err)
+	}
+}
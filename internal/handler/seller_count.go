@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// SellerItemCountGetter は出品者の出品数取得ユースケースの最小インターフェースです。
+type SellerItemCountGetter interface {
+	GetSellerItemCount(ctx context.Context, sellerID string) (int64, error)
+}
+
+// SellerItemCountPattern は SellerItemCountHandler を登録するルーティングパターンです
+const SellerItemCountPattern = "GET /v1/sellers/{sellerID}/count"
+
+// SellerItemCountHandler は出品者の出品中の商品の総数のみをJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれない軽量APIのため、net/http のハンドラーとして提供します
+type SellerItemCountHandler struct {
+	uc SellerItemCountGetter
+}
+
+// NewSellerItemCountHandler は新しいSellerItemCountHandlerインスタンスを作成します
+func NewSellerItemCountHandler(uc SellerItemCountGetter) *SellerItemCountHandler {
+	return &SellerItemCountHandler{
+		uc: uc,
+	}
+}
+
+// sellerItemCountResponse はJSONレスポンスの形式です
+type sellerItemCountResponse struct {
+	SellerID   string `json:"seller_id"`
+	TotalCount int64  `json:"total_count"`
+}
+
+// ServeHTTP はパスの sellerID から出品中の商品の総数を取得して返します
+func (h *SellerItemCountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sellerID := r.PathValue("sellerID")
+
+	count, err := h.uc.GetSellerItemCount(r.Context(), sellerID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sellerItemCountResponse{SellerID: sellerID, TotalCount: count}); err != nil {
+		log.Printf("warning: failed to write seller count response: %v", err)
+	}
+}
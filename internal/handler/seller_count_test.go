@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+type fakeSellerItemCountGetter struct {
+	count int64
+	err   error
+}
+
+func (f fakeSellerItemCountGetter) GetSellerItemCount(ctx context.Context, sellerID string) (int64, error) {
+	return f.count, f.err
+}
+
+func TestSellerItemCountHandler(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		getter     fakeSellerItemCountGetter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ok",
+			getter:     fakeSellerItemCountGetter{count: 42},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"seller_id\":\"seller1\",\"total_count\":42}\n",
+		},
+		{
+			name:       "no listings",
+			getter:     fakeSellerItemCountGetter{},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"seller_id\":\"seller1\",\"total_count\":0}\n",
+		},
+		{
+			name:       "invalid seller",
+			getter:     fakeSellerItemCountGetter{err: fmt.Errorf("seller id is required: %w", usecase.ErrInvalidArgument)},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(SellerItemCountPattern, NewSellerItemCountHandler(tc.getter))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/sellers/seller1/count", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("body got %q, want %q", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+type fakeSellerRatingGetter struct {
+	rating *model.SellerRating
+	err    error
+}
+
+func (f fakeSellerRatingGetter) GetSellerRating(ctx context.Context, sellerID string) (*model.SellerRating, error) {
+	return f.rating, f.err
+}
+
+func TestSellerRatingHandler_returnsJSON(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(SellerRatingPattern, NewSellerRatingHandler(fakeSellerRatingGetter{rating: &model.SellerRating{
+		SellerID: "seller1",
+		Total:    model.RatingCounts{Good: 10, Normal: 1, Bad: 2},
+	}}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/sellers/seller1/rating", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got sellerRatingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.SellerID != "seller1" || got.Total != (ratingCountsResponse{Good: 10, Normal: 1, Bad: 2}) {
+		t.Fatalf("response got %+v", got)
+	}
+}
+
+func TestSellerRatingHandler_invalidArgument(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(SellerRatingPattern, NewSellerRatingHandler(fakeSellerRatingGetter{
+		err: fmt.Errorf("seller id is required: %w", usecase.ErrInvalidArgument),
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/sellers/%20/rating", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// AuctionStatusGetter はオークション状態取得ユースケースの最小インターフェースです。
+type AuctionStatusGetter interface {
+	GetAuctionStatus(ctx context.Context, auctionID string) (model.Status, error)
+}
+
+// AuctionStatusPattern は AuctionStatusHandler を登録するルーティングパターンです
+const AuctionStatusPattern = "GET /v1/auctions/{auctionID}/status"
+
+// AuctionStatusHandler はオークションの状態のみをJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれない軽量APIのため、net/http のハンドラーとして提供します
+type AuctionStatusHandler struct {
+	uc AuctionStatusGetter
+}
+
+// NewAuctionStatusHandler は新しいAuctionStatusHandlerインスタンスを作成します
+func NewAuctionStatusHandler(uc AuctionStatusGetter) *AuctionStatusHandler {
+	return &AuctionStatusHandler{
+		uc: uc,
+	}
+}
+
+// auctionStatusResponse はJSONレスポンスの形式です
+type auctionStatusResponse struct {
+	AuctionID string `json:"auction_id"`
+	Status    string `json:"status"`
+}
+
+// ServeHTTP はパスの auctionID からオークションの状態を取得して返します
+func (h *AuctionStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auctionID := r.PathValue("auctionID")
+	if auctionID == "" {
+		http.Error(w, "auction id is required", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.uc.GetAuctionStatus(r.Context(), auctionID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(auctionStatusResponse{AuctionID: auctionID, Status: statusName(status)}); err != nil {
+		log.Printf("warning: failed to write status response: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+type fakeStatusGetter struct {
+	status model.Status
+	err    error
+}
+
+func (f fakeStatusGetter) GetAuctionStatus(ctx context.Context, auctionID string) (model.Status, error) {
+	return f.status, f.err
+}
+
+func TestAuctionStatusHandler(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		getter     fakeStatusGetter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "active",
+			getter:     fakeStatusGetter{status: model.StatusActive},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"auction_id\":\"x1\",\"status\":\"active\"}\n",
+		},
+		{
+			name:       "not found",
+			getter:     fakeStatusGetter{err: errors.New("not found")},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(AuctionStatusPattern, NewAuctionStatusHandler(tc.getter))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/status", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Fatalf("body got %q, want %q", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
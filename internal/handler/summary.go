@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// AuctionSummaryGetter はオークション概要取得ユースケースの最小インターフェースです。
+type AuctionSummaryGetter interface {
+	GetAuctionSummary(ctx context.Context, auctionID string) (*model.AuctionSummary, error)
+}
+
+// AuctionSummaryPattern は AuctionSummaryHandler を登録するルーティングパターンです
+const AuctionSummaryPattern = "GET /v1/auctions/{auctionID}/summary"
+
+// AuctionSummaryHandler はオークション概要をJSONで返すHTTPハンドラーです
+// protobufのサービス定義に含まれない軽量APIのため、net/http のハンドラーとして提供します
+type AuctionSummaryHandler struct {
+	uc AuctionSummaryGetter
+}
+
+// NewAuctionSummaryHandler は新しいAuctionSummaryHandlerインスタンスを作成します
+func NewAuctionSummaryHandler(uc AuctionSummaryGetter) *AuctionSummaryHandler {
+	return &AuctionSummaryHandler{
+		uc: uc,
+	}
+}
+
+// auctionSummaryResponse はJSONレスポンスの形式です
+// フィールド名はConnectのJSON表現（snake_case）に揃えます
+type auctionSummaryResponse struct {
+	AuctionID    string     `json:"auction_id"`
+	Title        string     `json:"title"`
+	CurrentPrice int64      `json:"current_price"`
+	Status       string     `json:"status"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+}
+
+// ServeHTTP はパスの auctionID からオークション概要を取得して返します
+func (h *AuctionSummaryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auctionID := r.PathValue("auctionID")
+	if auctionID == "" {
+		http.Error(w, "auction id is required", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.uc.GetAuctionSummary(r.Context(), auctionID)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newAuctionSummaryResponse(summary)); err != nil {
+		log.Printf("warning: failed to write summary response: %v", err)
+	}
+}
+
+// newAuctionSummaryResponse はドメインの AuctionSummary をJSONレスポンスの形式に変換します
+func newAuctionSummaryResponse(summary *model.AuctionSummary) auctionSummaryResponse {
+	resp := auctionSummaryResponse{
+		AuctionID:    summary.AuctionID,
+		Title:        summary.Title,
+		CurrentPrice: summary.CurrentPrice,
+		Status:       statusName(summary.Status),
+	}
+	if !summary.EndTime.IsZero() {
+		resp.EndTime = &summary.EndTime
+	}
+	return resp
+}
+
+// httpStatus はユースケースのエラーをHTTPステータスコードに変換します
+// 既知のエラーに該当しない場合は fallback を返します
+func httpStatus(err error, fallback int) int {
+	switch errorCode(err, connect.CodeUnknown) {
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return fallback
+	}
+}
+
+// statusName はドメインのStatusをAPIで返す文字列に変換します
+func statusName(s model.Status) string {
+	switch s {
+	case model.StatusActive:
+		return "active"
+	case model.StatusFinished:
+		return "finished"
+	case model.StatusCanceled:
+		return "canceled"
+	default:
+		return "unspecified"
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+type fakeSummaryGetter struct {
+	summary *model.AuctionSummary
+	err     error
+}
+
+func (f fakeSummaryGetter) GetAuctionSummary(ctx context.Context, auctionID string) (*model.AuctionSummary, error) {
+	return f.summary, f.err
+}
+
+func newSummaryMux(uc AuctionSummaryGetter) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(AuctionSummaryPattern, NewAuctionSummaryHandler(uc))
+	return mux
+}
+
+func TestAuctionSummaryHandler_returnsJSON(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 12, 30, 16, 0, 10, 0, time.FixedZone("JST", 9*60*60))
+	mux := newSummaryMux(fakeSummaryGetter{summary: &model.AuctionSummary{
+		AuctionID:    "x1234567890",
+		Title:        "title",
+		CurrentPrice: 1234,
+		Status:       model.StatusActive,
+		EndTime:      end,
+	}})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1234567890/summary", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got auctionSummaryResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.AuctionID != "x1234567890" || got.CurrentPrice != 1234 || got.Status != "active" {
+		t.Fatalf("got %+v", got)
+	}
+	if got.EndTime == nil || !got.EndTime.Equal(end) {
+		t.Fatalf("EndTime got %v, want %v", got.EndTime, end)
+	}
+}
+
+func TestAuctionSummaryHandler_returnsNotFoundOnUsecaseError(t *testing.T) {
+	t.Parallel()
+
+	mux := newSummaryMux(fakeSummaryGetter{err: errors.New("not found")})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/summary", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status got %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestAuctionSummaryHandler_mapsFetchErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "page gone", err: fmt.Errorf("status 410: %w", repository.ErrNotFound), want: http.StatusNotFound},
+		{name: "rate limited", err: fmt.Errorf("status 429: %w", repository.ErrRateLimited), want: http.StatusTooManyRequests},
+		{name: "server error", err: fmt.Errorf("status 500: %w", repository.ErrUnexpectedStatus), want: http.StatusServiceUnavailable},
+		{name: "transport error", err: fmt.Errorf("%w: i/o timeout", repository.ErrFetchFailed), want: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := newSummaryMux(fakeSummaryGetter{err: tc.err})
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auctions/x1/summary", nil))
+
+			if rec.Code != tc.want {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// WatchlistRefresher はウォッチリストの状態をまとめて取得するユースケースの最小インターフェースです。
+type WatchlistRefresher interface {
+	RefreshWatchlist(ctx context.Context, auctionIDs []string) ([]model.Result[*model.AuctionSnapshot], error)
+}
+
+// WatchlistRefreshPattern は WatchlistRefreshHandler を登録するルーティングパターンです
+const WatchlistRefreshPattern = "GET /v1/watchlist/refresh"
+
+// WatchlistRefreshHandler はウォッチリストの各オークションの状態と現在価格をまとめてJSONで返すHTTPハンドラーです
+// 一部のIDの取得に失敗しても、成功したスナップショットと失敗したIDごとのエラーを 200 で返します
+type WatchlistRefreshHandler struct {
+	uc WatchlistRefresher
+}
+
+// NewWatchlistRefreshHandler は新しいWatchlistRefreshHandlerインスタンスを作成します
+func NewWatchlistRefreshHandler(uc WatchlistRefresher) *WatchlistRefreshHandler {
+	return &WatchlistRefreshHandler{
+		uc: uc,
+	}
+}
+
+// watchlistRefreshResponse はJSONレスポンスの形式です
+type watchlistRefreshResponse struct {
+	Snapshots []auctionSnapshotResponse `json:"snapshots"`
+	Errors    []batchError              `json:"errors"`
+}
+
+// auctionSnapshotResponse はスナップショット1件のJSONの形式です
+type auctionSnapshotResponse struct {
+	AuctionID    string     `json:"auction_id"`
+	Status       string     `json:"status"`
+	CurrentPrice int64      `json:"current_price"`
+	BidCount     int64      `json:"bid_count"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+}
+
+// ServeHTTP はクエリの ids（カンマ区切り）の各オークションのスナップショットを取得して返します
+func (h *WatchlistRefreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	// 多数のIDの取得はサーバーの WriteTimeout より長くかかることがあるため、書き込み期限を解除する
+	// 処理全体の時間は RefreshWatchlist の時間の上限（WithWatchlistTimeout）とリクエストの ctx で制限される
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("warning: failed to clear write deadline for watchlist refresh: %v", err)
+	}
+
+	results, err := h.uc.RefreshWatchlist(r.Context(), ids)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	resp := watchlistRefreshResponse{
+		Snapshots: make([]auctionSnapshotResponse, 0, len(results)),
+		Errors:    make([]batchError, 0),
+	}
+	for _, res := range results {
+		if !res.OK() {
+			resp.Errors = append(resp.Errors, batchError{AuctionID: res.ID, Error: res.Err.Error()})
+			continue
+		}
+		s := res.Value
+		snapshot := auctionSnapshotResponse{
+			AuctionID:    s.AuctionID,
+			Status:       statusName(s.Status),
+			CurrentPrice: s.CurrentPrice,
+			BidCount:     s.BidCount,
+		}
+		if !s.EndTime.IsZero() {
+			snapshot.EndTime = &s.EndTime
+		}
+		resp.Snapshots = append(resp.Snapshots, snapshot)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("warning: failed to write watchlist response: %v", err)
+	}
+}
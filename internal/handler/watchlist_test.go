@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/memory"
+	"jo3qma.com/yahoo_auctions/internal/usecase"
+)
+
+func TestWatchlistRefreshHandler(t *testing.T) {
+	t.Parallel()
+
+	uc := usecase.NewAuctionUsecase(memory.NewItemRepository(
+		&model.Item{AuctionID: "x1", CurrentPrice: 100, BidCount: 2, Status: model.StatusActive},
+	))
+
+	cases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "partial success",
+			query:      "?ids=x1,x2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"snapshots":[{"auction_id":"x1","status":"active","current_price":100,"bid_count":2}],` +
+				`"errors":[{"auction_id":"x2","error":"auction x2: not found"}]}` + "\n",
+		},
+		{
+			name:       "no ids",
+			query:      "?ids=",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.Handle(WatchlistRefreshPattern, NewWatchlistRefreshHandler(uc))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/watchlist/refresh"+tc.query, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("body got %s, want %s", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
+
+// slowWatchlistRefresher は delay だけ待機してから、すべてのIDのスナップショットを返すフェイクです
+type slowWatchlistRefresher struct {
+	delay time.Duration
+}
+
+func (f slowWatchlistRefresher) RefreshWatchlist(ctx context.Context, auctionIDs []string) ([]model.Result[*model.AuctionSnapshot], error) {
+	time.Sleep(f.delay)
+	results := make([]model.Result[*model.AuctionSnapshot], len(auctionIDs))
+	for i, id := range auctionIDs {
+		results[i] = model.Result[*model.AuctionSnapshot]{ID: id, Value: &model.AuctionSnapshot{AuctionID: id, Status: model.StatusActive}}
+	}
+	return results, nil
+}
+
+func TestWatchlistRefreshHandler_outlivesWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(WatchlistRefreshPattern, NewWatchlistRefreshHandler(slowWatchlistRefresher{delay: 200 * time.Millisecond}))
+
+	// 取得全体（約200ms）よりも短い WriteTimeout のサーバーで実行する
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/v1/watchlist/refresh?ids=x1,x2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body watchlistRefreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if len(body.Snapshots) != 2 {
+		t.Errorf("snapshots got %d, want 2", len(body.Snapshots))
+	}
+}
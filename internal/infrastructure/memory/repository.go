@@ -0,0 +1,173 @@
+// Package memory はリポジトリインターフェースのインメモリ実装を提供します。
+// ヤフオクにアクセスせずにユースケースやハンドラーを結合テストするためのテストダブルとして利用します。
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+// ErrNotFound は事前に登録されていないデータを要求された場合に返されます
+// 実際のリポジトリと同じく repository.ErrNotFound として判定できます
+var ErrNotFound = repository.ErrNotFound
+
+var (
+	_ repository.ItemRepository         = (*ItemRepository)(nil)
+	_ repository.CategoryItemRepository = (*CategoryItemRepository)(nil)
+)
+
+// ItemRepository はオークションIDをキーに商品を保持するインメモリ実装です
+// 複数のgoroutineから安全に利用できます
+type ItemRepository struct {
+	mu    sync.RWMutex
+	items map[string]*model.Item
+}
+
+// NewItemRepository は新しいItemRepositoryを作成します
+// 引数で渡した商品は AuctionID をキーとして事前登録されます
+func NewItemRepository(items ...*model.Item) *ItemRepository {
+	r := &ItemRepository{
+		items: make(map[string]*model.Item, len(items)),
+	}
+	for _, item := range items {
+		r.Set(item)
+	}
+	return r
+}
+
+// Set は商品を AuctionID をキーとして登録します。既存の商品は上書きされます
+func (r *ItemRepository) Set(item *model.Item) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[item.AuctionID] = item
+}
+
+// Get は登録済みの商品を返します
+func (r *ItemRepository) Get(auctionID string) (*model.Item, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	item, ok := r.items[auctionID]
+	return item, ok
+}
+
+// FetchByID は登録済みの商品を返します。未登録の場合は ErrNotFound を返します
+func (r *ItemRepository) FetchByID(ctx context.Context, auctionID string) (*model.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	item, ok := r.Get(auctionID)
+	if !ok {
+		return nil, fmt.Errorf("auction %s: %w", auctionID, ErrNotFound)
+	}
+	return item, nil
+}
+
+// FetchByIDWithFields は登録済みの商品のうち、fields に含まれない任意フィールドを
+// ゼロ値にしたコピーを返します。未登録の場合は ErrNotFound を返します
+func (r *ItemRepository) FetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	item, err := r.FetchByID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	masked := *item
+	if !fields.Has(model.ItemFieldDescription) {
+		masked.Description = ""
+		masked.DescriptionText = ""
+		masked.RawDescriptionText = ""
+	}
+	if !fields.Has(model.ItemFieldImages) {
+		masked.Images = nil
+	}
+	if !fields.Has(model.ItemFieldSeller) {
+		masked.Seller = nil
+	}
+	if !fields.Has(model.ItemFieldRelatedItems) {
+		masked.RelatedItems = nil
+	}
+	if !fields.Has(model.ItemFieldQuestions) {
+		masked.Questions = nil
+	}
+	return &masked, nil
+}
+
+// FetchStatus は登録済みの商品の状態を返します。未登録の場合は ErrNotFound を返します
+func (r *ItemRepository) FetchStatus(ctx context.Context, auctionID string) (model.Status, error) {
+	item, err := r.FetchByID(ctx, auctionID)
+	if err != nil {
+		return model.StatusUnspecified, err
+	}
+	return item.Status, nil
+}
+
+// categoryPageKey はカテゴリIDとページ番号の組です
+type categoryPageKey struct {
+	categoryID string
+	page       int64
+}
+
+// CategoryItemRepository はカテゴリIDとページ番号をキーに商品一覧を保持するインメモリ実装です
+// 複数のgoroutineから安全に利用できます
+type CategoryItemRepository struct {
+	mu    sync.RWMutex
+	pages map[categoryPageKey]*model.CategoryItemsPage
+}
+
+// NewCategoryItemRepository は新しいCategoryItemRepositoryを作成します
+func NewCategoryItemRepository() *CategoryItemRepository {
+	return &CategoryItemRepository{
+		pages: make(map[categoryPageKey]*model.CategoryItemsPage),
+	}
+}
+
+// Set は指定したカテゴリ・ページの商品一覧を登録します。既存のページは上書きされます
+func (r *CategoryItemRepository) Set(categoryID string, page int64, p *model.CategoryItemsPage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages[categoryPageKey{categoryID: categoryID, page: page}] = p
+}
+
+// Get は登録済みの商品一覧を返します
+func (r *CategoryItemRepository) Get(categoryID string, page int64) (*model.CategoryItemsPage, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.pages[categoryPageKey{categoryID: categoryID, page: page}]
+	return p, ok
+}
+
+// FetchByCategory は登録済みの商品一覧を返します。未登録の場合は ErrNotFound を返します
+// opts の検索条件は考慮せず、登録した一覧をそのまま返します
+func (r *CategoryItemRepository) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p, ok := r.Get(categoryID, page)
+	if !ok {
+		return nil, fmt.Errorf("category %s page %d: %w", categoryID, page, ErrNotFound)
+	}
+	return p, nil
+}
+
+// FetchByCategoryOffset は offset / limit をページ番号とみなして登録済みの商品一覧を返します
+// limit 件ごとのページとして登録されている前提で、ページの途中からの取得は表現しません
+func (r *CategoryItemRepository) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, fmt.Errorf("category %s offset %d limit %d: %w", categoryID, offset, limit, ErrNotFound)
+	}
+	return r.FetchByCategory(ctx, categoryID, offset/limit, opts)
+}
+
+// FetchItemCount は登録済みの0ページ目の TotalCount を返します。未登録の場合は ErrNotFound を返します
+func (r *CategoryItemRepository) FetchItemCount(ctx context.Context, categoryID string) (int64, error) {
+	p, err := r.FetchByCategory(ctx, categoryID, 0, model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return p.TotalCount, nil
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestItemRepository_FetchByID_returnsSeededItem(t *testing.T) {
+	t.Parallel()
+
+	want := &model.Item{AuctionID: "x1234567890", Title: "title"}
+	repo := NewItemRepository(want)
+
+	got, err := repo.FetchByID(context.Background(), "x1234567890")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestItemRepository_FetchByID_returnsNotFound(t *testing.T) {
+	t.Parallel()
+
+	repo := NewItemRepository()
+
+	_, err := repo.FetchByID(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestItemRepository_FetchByIDWithFields_masksUnrequestedFields(t *testing.T) {
+	t.Parallel()
+
+	seeded := &model.Item{
+		AuctionID:   "a1",
+		Description: "<p>desc</p>",
+		Images:      []string{"https://example.com/1.jpg"},
+	}
+	repo := NewItemRepository(seeded)
+
+	got, err := repo.FetchByIDWithFields(context.Background(), "a1", model.ItemFieldImages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Description != "" {
+		t.Fatalf("Description got %q, want empty", got.Description)
+	}
+	if len(got.Images) != 1 {
+		t.Fatalf("Images len got %d, want 1", len(got.Images))
+	}
+	if seeded.Description == "" {
+		t.Fatalf("seeded item must not be modified")
+	}
+}
+
+func TestItemRepository_SetOverwrites(t *testing.T) {
+	t.Parallel()
+
+	repo := NewItemRepository(&model.Item{AuctionID: "a1", Title: "old"})
+	repo.Set(&model.Item{AuctionID: "a1", Title: "new"})
+
+	got, ok := repo.Get("a1")
+	if !ok {
+		t.Fatalf("item not found")
+	}
+	if got.Title != "new" {
+		t.Fatalf("Title got %q, want %q", got.Title, "new")
+	}
+}
+
+func TestCategoryItemRepository_FetchByCategory(t *testing.T) {
+	t.Parallel()
+
+	want := &model.CategoryItemsPage{
+		Items:      []*model.CategoryItem{{AuctionID: "a1"}},
+		TotalCount: 1,
+	}
+	repo := NewCategoryItemRepository()
+	repo.Set("2084261685", 0, want)
+
+	got, err := repo.FetchByCategory(context.Background(), "2084261685", 0, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	_, err = repo.FetchByCategory(context.Background(), "2084261685", 1, model.CategorySearchOptions{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCategoryItemRepository_FetchByCategory_respectsCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	repo := NewCategoryItemRepository()
+	repo.Set("1", 0, &model.CategoryItemsPage{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.FetchByCategory(ctx, "1", 0, model.CategorySearchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+}
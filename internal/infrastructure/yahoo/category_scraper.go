@@ -3,69 +3,248 @@ package yahoo
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/trace"
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
 	"jo3qma.com/yahoo_auctions/internal/domain/repository"
 )
 
+// CategoryItemsPerPage はページ番号で取得する場合（FetchByCategory）の1ページあたりの商品数です
+const CategoryItemsPerPage int64 = 50
+
+// noResultsMarker は検索条件に該当する商品がない場合に一覧ページに表示される文言です
+const noResultsMarker = "該当する商品がありません"
+
 type yahooCategoryScraper struct {
 	client  *http.Client
 	baseURL string
+	tracer  trace.Tracer
+	retry   retryPolicy
+
+	validators *validatorCache // nil の場合は条件付きリクエストを送信しない
+	solver     ChallengeSolver // nil の場合は確認ページを通過せずにエラーとする
+
+	debugDumpDir string // 空でない場合、取得したHTMLをこのディレクトリに書き出す
+
+	verifyHasNext bool // true の場合、HasNext を総件数との比較で判定する
+
+	now func() time.Time // 残り時間の表記から終了日時を求める際の現在時刻
+
+	stats *requestStats // リクエスト数と最後に成功した日時
 }
 
 // NewYahooCategoryScraper は新しいCategoryItemRepositoryの実装を作成します
-func NewYahooCategoryScraper() repository.CategoryItemRepository {
+// opts でベースURLや http.Client を変更できます
+func NewYahooCategoryScraper(opts ...Option) repository.CategoryItemRepository {
+	return newYahooCategoryScraper(newOptions(defaultCategoryBaseURL, opts))
+}
+
+// newYahooCategoryScraper はテスト容易性のための内部コンストラクタです。
+func newYahooCategoryScraper(o *options) repository.CategoryItemRepository {
 	return &yahooCategoryScraper{
-		client:  &http.Client{Timeout: 30 * time.Second},
-		baseURL: "https://auctions.yahoo.co.jp",
+		client:  o.client,
+		baseURL: o.baseURL,
+		tracer:  o.tracer,
+		retry:   o.retry,
+
+		validators: o.validators,
+		solver:     o.solver,
+
+		debugDumpDir: o.debugDumpDir,
+
+		verifyHasNext: o.verifyHasNext,
+
+		now: o.now,
+
+		stats: &requestStats{},
+	}
+}
+
+// Stats はこのスクレイパーのリクエスト数と最後に成功した日時を返します
+func (s *yahooCategoryScraper) Stats() Stats {
+	return s.stats.snapshot()
+}
+
+// FetchByCategory は page 番目（0 始まり）のページを取得します
+// ページ番号は FetchByCategoryOffset のオフセットに変換されます
+func (s *yahooCategoryScraper) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	return s.FetchByCategoryOffset(ctx, categoryID, CategoryItemsPerPage*page, CategoryItemsPerPage, opts)
+}
+
+// FetchByCategoryOffset は offset 件目（0 始まり）以降の商品を limit 件取得します
+func (s *yahooCategoryScraper) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (result *model.CategoryItemsPage, err error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid offset %d: must not be negative", offset)
+	}
+	if !slices.Contains(model.CategoryPageLimits, limit) {
+		return nil, fmt.Errorf("invalid limit %d: must be one of %v", limit, model.CategoryPageLimits)
 	}
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchByCategory", attrCategoryID.String(categoryID))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	targetURL, err := s.buildCategoryURL(categoryID, offset, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attrURL.String(targetURL))
+
+	// 共通関数でHTML取得
+	doc, err := fetchHTML(ctx, s.client, targetURL, s.retry, s.validators, s.solver)
+	if err != nil {
+		return nil, err
+	}
+	dumpDocument(s.debugDumpDir, "category", fmt.Sprintf("%s_b%d", categoryID, offset), doc, time.Now())
+
+	// パース
+	page, err := s.extractCategoryItems(doc, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if s.verifyHasNext {
+		page.HasNext = verifiedHasNext(categoryID, offset, page)
+	}
+	return page, nil
 }
 
-func (s *yahooCategoryScraper) FetchByCategory(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error) {
+// verifiedHasNext は取得位置と件数が総件数に達しているかで次のページの有無を判定します
+// 総件数を取得できなかった場合は件数による簡易判定（page.HasNext）をそのまま返し、その旨をログに残します
+func verifiedHasNext(categoryID string, offset int64, page *model.CategoryItemsPage) bool {
+	if page.TotalCount <= 0 {
+		log.Printf("warning: total count for category %s is not available, has_next=%t is estimated from item count", categoryID, page.HasNext)
+		return page.HasNext
+	}
+	return offset+int64(len(page.Items)) < page.TotalCount
+}
+
+// FetchItemCount はカテゴリ商品一覧の最初のページから商品の総数のみを取得します
+// 転送量を抑えるため表示件数が最小のページを取得し、各商品の抽出は行いません
+func (s *yahooCategoryScraper) FetchItemCount(ctx context.Context, categoryID string) (count int64, err error) {
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchItemCount", attrCategoryID.String(categoryID))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	targetURL, err := s.buildCategoryURL(categoryID, 0, slices.Min(model.CategoryPageLimits), model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	span.SetAttributes(attrURL.String(targetURL))
+
+	doc, err := fetchHTML(ctx, s.client, targetURL, s.retry, s.validators, s.solver)
+	if err != nil {
+		return 0, err
+	}
+	return parseTotalCount(doc), nil
+}
+
+// buildCategoryURL はカテゴリ商品一覧ページのURLを構築します
+// offset は 0 始まりの取得位置、limit は取得件数です
+func (s *yahooCategoryScraper) buildCategoryURL(categoryID string, offset, limit int64, opts model.CategorySearchOptions) (string, error) {
 	// URL構築
 	// 例: https://auctions.yahoo.co.jp/category/list/{categoryID}/?p=&auccat={categoryID}&is_postage_mode=1&dest_pref_code=27&b={offset}&n=50&s1=new&o1=d
 
-	// b (offset) の計算: (1ページあたりの商品数 * (ページ番号)) + 1
-	// pageは0始まりとする仕様なので、0ページ目は 1, 1ページ目は 51
-	const itemsPerPage = 50
-	offset := (itemsPerPage * page) + 1
-
 	u, err := url.Parse(fmt.Sprintf("%s/category/list/%s/", s.baseURL, categoryID))
 	if err != nil {
-		return nil, fmt.Errorf("invalid base url: %w", err)
+		return "", fmt.Errorf("invalid base url: %w", err)
 	}
 
 	q := u.Query()
 	q.Set("auccat", categoryID)
+	// is_postage_mode=1 は送料込みの価格表示を有効にし、dest_pref_code はその送料計算に使う配送先の都道府県（27: 大阪府）
+	// いずれも表示のための指定であり、送料無料での絞り込み（pstagefree）とは独立している
 	q.Set("is_postage_mode", "1")
 	q.Set("dest_pref_code", "27")
-	q.Set("b", strconv.FormatInt(offset, 10))
-	q.Set("n", strconv.FormatInt(int64(itemsPerPage), 10))
-	q.Set("s1", "new")
-	q.Set("o1", "d")
-	// p (検索ワード) は指定しない
+	// b は 1 始まりのため、0 始まりの offset に 1 を加える（offset 0 は 1, offset 50 は 51）
+	q.Set("b", strconv.FormatInt(offset+1, 10))
+	q.Set("n", strconv.FormatInt(limit, 10))
+	// 並び順: 通常は新着順、EndingSoon 指定時は終了時間の近い順
+	if opts.EndingSoon {
+		q.Set("s1", "end")
+		q.Set("o1", "a")
+	} else {
+		q.Set("s1", "new")
+		q.Set("o1", "d")
+	}
+	// p (検索ワード) はキーワード指定時のみ設定し、カテゴリ内を絞り込む
+	if opts.Keyword != "" {
+		q.Set("p", opts.Keyword)
+	}
+	// pstagefree (送料無料) は絞り込む場合のみ指定する
+	if opts.FreeShippingOnly {
+		q.Set("pstagefree", "1")
+	}
+	// new (新着) はヤフオクの新着フィルタ（24時間以内）に対応する
+	// 24時間を超える期間には対応するパラメータがないため、その場合は指定しない（ユースケースで不正な条件として扱う）
+	if opts.NewlyListedWithin > 0 && opts.NewlyListedWithin <= model.MaxNewlyListedWithin {
+		q.Set("new", "1")
+	}
+	// abatch (出品者の種別) は 1 がストア、2 が個人。絞り込む場合のみ指定する
+	switch opts.SellerType {
+	case model.SellerTypeStore:
+		q.Set("abatch", "1")
+	case model.SellerTypeIndividual:
+		q.Set("abatch", "2")
+	}
+	// ve (除外キーワード) はスペース区切りで指定する
+	if len(opts.ExcludeKeywords) > 0 {
+		q.Set("ve", strings.Join(opts.ExcludeKeywords, " "))
+	}
 
 	u.RawQuery = q.Encode()
-	targetURL := u.String()
+	return u.String(), nil
+}
 
-	// 共通関数でHTML取得
-	doc, err := fetchHTML(ctx, s.client, targetURL)
-	if err != nil {
-		return nil, err
+// parsePostage は一覧の送料表記（例: "＋送料800円"、"送料無料"）から送料を抽出します
+// 着払いなど金額が表示されていない場合は false を返します
+func parsePostage(text string) (int64, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, false
+	}
+	if strings.Contains(text, "無料") {
+		return 0, true
 	}
+	if !strings.Contains(normalizeDigits(text), "円") {
+		return 0, false
+	}
+	return parsePrice(text), true
+}
 
-	// パース
-	return s.extractCategoryItems(doc)
+// parseShippingPayer は一覧の送料表記から送料の負担者を判定します
+// 「送料無料」「出品者負担」は出品者、「落札者負担」「着払い」や送料の金額表示（例: "＋送料800円"）は落札者とみなします
+func parseShippingPayer(text string) model.ShippingPayer {
+	text = normalizeDigits(strings.TrimSpace(text))
+	switch {
+	case text == "":
+		return model.ShippingPayerUnknown
+	case strings.Contains(text, "出品者負担"), strings.Contains(text, "無料"):
+		return model.ShippingPayerSeller
+	case strings.Contains(text, "落札者負担"), strings.Contains(text, "着払い"):
+		return model.ShippingPayerBuyer
+	case strings.Contains(text, "送料") && strings.Contains(text, "円"):
+		return model.ShippingPayerBuyer
+	default:
+		return model.ShippingPayerUnknown
+	}
 }
 
-func (s *yahooCategoryScraper) extractCategoryItems(doc *goquery.Document) (*model.CategoryItemsPage, error) {
+// extractCategoryItems は一覧ページのHTMLから商品を抽出します。offset は取得位置（0 始まり）、limit は要求した取得件数です
+func (s *yahooCategoryScraper) extractCategoryItems(doc *goquery.Document, offset, limit int64) (*model.CategoryItemsPage, error) {
 	var items []*model.CategoryItem
+	now := currentTime(s.now)
 
 	// 商品一覧: div.Products__list ul.Products__items li.Product
 	doc.Find("div.Products__list ul.Products__items li.Product").Each(func(i int, s *goquery.Selection) {
@@ -80,6 +259,17 @@ func (s *yahooCategoryScraper) extractCategoryItems(doc *goquery.Document) (*mod
 			item.AuctionID = id
 		}
 
+		// 開始日時・終了日時: a.Product__titleLink (data-auction-starttime / data-auction-endtime, UNIX秒)
+		// 開始日時は一覧によっては出力されないため、その場合はゼロ値のままとする
+		item.StartTime = unixTimeAttr(titleLink, "data-auction-starttime")
+		item.EndTime = unixTimeAttr(titleLink, "data-auction-endtime")
+		// 終了日時の属性がない場合は、残り時間の表記（例: 残り 3時間）から求める
+		if item.EndTime.IsZero() {
+			if t, err := parseRelativeTime(s.Find(".Product__time").First().Text(), now); err == nil {
+				item.EndTime = t
+			}
+		}
+
 		// 画像: div.Products__list ul.Products__items li.Product img.Product__imageData
 		// src属性を取得。遅延ロードなどで src がダミーの場合、data-src 等を見る必要があるかもしれないが、
 		// @Untitled-1 の指定通りまずは普通に取得する。
@@ -107,20 +297,71 @@ func (s *yahooCategoryScraper) extractCategoryItems(doc *goquery.Document) (*mod
 			item.ImmediatePrice = parsePrice(immediatePriceEl.Text())
 		}
 
+		// 開始価格: a.Product__titleLink (data-auction-startprice)。属性がない場合は .Product__startPrice の表記（例: "開始 800円"）
+		// 入札のない商品（CurrentPrice == StartPrice かつ BidCount == 0）の判定に使う
+		if v, exists := titleLink.Attr("data-auction-startprice"); exists {
+			item.StartPrice = parsePrice(v)
+		} else {
+			item.StartPrice = parsePrice(s.Find(".Product__startPrice").First().Text())
+		}
+
+		// 送料込み価格: is_postage_mode=1 の場合に表示される送料（dest_pref_code 宛て）を加算する
+		postage := s.Find(".Product__postage").First().Text()
+		item.TotalPrice = item.CurrentPrice
+		if shipping, ok := parsePostage(postage); ok {
+			item.TotalPrice += shipping
+		}
+		item.ShippingPayer = parseShippingPayer(postage)
+
+		// 商品の状態: .Product__condition（表示されない一覧もある）
+		item.Condition = parseCondition(s.Find(".Product__condition").First().Text())
+
+		// 個数: .Product__quantity（例: "残り3個"）。複数個の出品でのみ表示されるため、ない場合は1とする
+		item.Quantity = 1
+		if n := parseCount(s.Find(".Product__quantity").First().Text()); n > 0 {
+			item.Quantity = n
+		}
+
 		// 入札数: dd.Product__bid
 		bidEl := s.Find("dd.Product__bid")
 		item.BidCount = parseCount(bidEl.Text())
 
+		// 順位: 表示順をカテゴリ全体での順位（1 始まり）として保持する
+		item.Rank = int(offset) + len(items) + 1
+
 		items = append(items, item)
 	})
 
-	// 商品の総数: div.Result__header > div.SearchMode > div.Tab > ul > li.Tab__item.Tab__item--current > div > span.Tab__subText
-	totalCountStr := doc.Find("div.Result__header div.SearchMode div.Tab ul li.Tab__item--current div span.Tab__subText").Text()
-	totalCount := parseCount(totalCountStr)
-
-	return &model.CategoryItemsPage{
+	page := &model.CategoryItemsPage{
 		Items:      items,
-		TotalCount: totalCount,
-		HasNext:    len(items) >= 50, // 簡易判定
-	}, nil
+		TotalCount: parseTotalCount(doc),
+		HasNext:    int64(len(items)) >= limit, // 簡易判定
+	}
+	// 商品が0件の場合、該当なしの表示があれば意図した空の結果、なければ抽出の失敗を疑う
+	if len(items) == 0 {
+		page.NoResults = strings.Contains(doc.Find("body").Text(), noResultsMarker)
+		if !page.NoResults {
+			log.Printf("warning: no items were extracted from category page and no-results marker %q was not found", noResultsMarker)
+		}
+	}
+	return page, nil
+}
+
+// unixTimeAttr は要素の属性 name の値（UNIX秒）を日時として返します。属性がない場合や不正な値の場合はゼロ値を返します
+func unixTimeAttr(sel *goquery.Selection, name string) time.Time {
+	v, exists := sel.Attr(name)
+	if !exists {
+		return time.Time{}
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// parseTotalCount は一覧ページのHTMLから商品の総数を抽出します。表示がない場合は0を返します
+func parseTotalCount(doc *goquery.Document) int64 {
+	// 商品の総数: div.Result__header > div.SearchMode > div.Tab > ul > li.Tab__item.Tab__item--current > div > span.Tab__subText
+	return parseCount(doc.Find("div.Result__header div.SearchMode div.Tab ul li.Tab__item--current div span.Tab__subText").Text())
 }
@@ -1,10 +1,17 @@
 package yahoo
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
 )
 
 func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
@@ -31,7 +38,7 @@ func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
 			<li class="Product">
 				<div class="Product__detail">
 					<h3 class="Product__title">
-						<a href="#" class="Product__titleLink" data-auction-id="a123456789">Test Item 1</a>
+						<a href="#" class="Product__titleLink" data-auction-id="a123456789" data-auction-starttime="1766473210" data-auction-endtime="1767078010" data-auction-startprice="800">Test Item 1</a>
 					</h3>
 				</div>
 				<div class="Product__priceInfo">
@@ -41,8 +48,11 @@ func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
 					<span class="Product__price">
 						<span class="Product__priceValue">2,000円</span>
 					</span>
+					<p class="Product__postage">＋送料800円</p>
 				</div>
 				<dd class="Product__bid">5</dd>
+				<span class="Product__condition">未使用に近い</span>
+				<span class="Product__quantity">残り３個</span>
 				<img class="Product__imageData" src="http://example.com/img1.jpg">
 			</li>
 			<li class="Product">
@@ -72,7 +82,7 @@ func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
 	}
 
 	scraper := &yahooCategoryScraper{}
-	page, err := scraper.extractCategoryItems(doc)
+	page, err := scraper.extractCategoryItems(doc, 0, CategoryItemsPerPage)
 	if err != nil {
 		t.Fatalf("extractCategoryItems failed: %v", err)
 	}
@@ -101,12 +111,33 @@ func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
 	if item1.ImmediatePrice != 2000 {
 		t.Errorf("Item1 ImmediatePrice got %d, want 2000", item1.ImmediatePrice)
 	}
+	if item1.StartPrice != 800 {
+		t.Errorf("Item1 StartPrice got %d, want 800", item1.StartPrice)
+	}
+	if item1.TotalPrice != 1800 {
+		t.Errorf("Item1 TotalPrice got %d, want 1800", item1.TotalPrice)
+	}
+	if item1.ShippingPayer != model.ShippingPayerBuyer {
+		t.Errorf("Item1 ShippingPayer got %v, want %v", item1.ShippingPayer, model.ShippingPayerBuyer)
+	}
+	if item1.Condition != model.ConditionLikeNew {
+		t.Errorf("Item1 Condition got %v, want %v", item1.Condition, model.ConditionLikeNew)
+	}
+	if item1.Quantity != 3 {
+		t.Errorf("Item1 Quantity got %d, want 3", item1.Quantity)
+	}
 	if item1.BidCount != 5 {
 		t.Errorf("Item1 BidCount got %d, want 5", item1.BidCount)
 	}
 	if item1.Image != "http://example.com/img1.jpg" {
 		t.Errorf("Item1 Image got %s, want http://example.com/img1.jpg", item1.Image)
 	}
+	if !item1.StartTime.Equal(time.Unix(1766473210, 0)) {
+		t.Errorf("Item1 StartTime got %v, want %v", item1.StartTime, time.Unix(1766473210, 0))
+	}
+	if !item1.EndTime.Equal(time.Unix(1767078010, 0)) {
+		t.Errorf("Item1 EndTime got %v, want %v", item1.EndTime, time.Unix(1767078010, 0))
+	}
 
 	// Item 2
 	item2 := page.Items[1]
@@ -116,16 +147,501 @@ func TestYahooCategoryScraper_extractCategoryItems(t *testing.T) {
 	if item2.CurrentPrice != 500 {
 		t.Errorf("Item2 CurrentPrice got %d, want 500", item2.CurrentPrice)
 	}
+	if item2.Condition != model.ConditionUnspecified {
+		t.Errorf("Item2 Condition got %v, want %v", item2.Condition, model.ConditionUnspecified)
+	}
+	if item2.ShippingPayer != model.ShippingPayerUnknown {
+		t.Errorf("Item2 ShippingPayer got %v, want %v", item2.ShippingPayer, model.ShippingPayerUnknown)
+	}
+	if item2.TotalPrice != 500 {
+		t.Errorf("Item2 TotalPrice got %d, want 500", item2.TotalPrice)
+	}
+	if item2.Quantity != 1 {
+		t.Errorf("Item2 Quantity got %d, want 1", item2.Quantity)
+	}
 	if item2.ImmediatePrice != 0 {
 		t.Errorf("Item2 ImmediatePrice got %d, want 0", item2.ImmediatePrice)
 	}
+	if item2.StartPrice != 0 {
+		t.Errorf("Item2 StartPrice got %d, want 0", item2.StartPrice)
+	}
 	if item2.Image != "http://example.com/img2.jpg" {
 		t.Errorf("Item2 Image got %s, want http://example.com/img2.jpg", item2.Image)
 	}
+	if !item2.EndTime.IsZero() {
+		t.Errorf("Item2 EndTime got %v, want zero", item2.EndTime)
+	}
+	if !item2.StartTime.IsZero() {
+		t.Errorf("Item2 StartTime got %v, want zero", item2.StartTime)
+	}
+}
+
+func TestYahooCategoryScraper_extractCategoryItems_startPriceText(t *testing.T) {
+	t.Parallel()
+
+	html := `
+<div class="Products__list">
+	<ul class="Products__items">
+		<li class="Product">
+			<h3 class="Product__title"><a class="Product__titleLink" data-auction-id="c1">No bids</a></h3>
+			<div class="Product__priceInfo">
+				<span class="Product__price"><span class="Product__priceValue">1,500円</span></span>
+				<span class="Product__startPrice">開始 1,500円</span>
+			</div>
+			<dd class="Product__bid">0</dd>
+		</li>
+	</ul>
+</div>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to parse html: %v", err)
+	}
+
+	page, err := (&yahooCategoryScraper{}).extractCategoryItems(doc, 0, CategoryItemsPerPage)
+	if err != nil {
+		t.Fatalf("extractCategoryItems failed: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("Items len got %d, want 1", len(page.Items))
+	}
+	item := page.Items[0]
+	if item.StartPrice != 1500 {
+		t.Errorf("StartPrice got %d, want 1500", item.StartPrice)
+	}
+	if item.CurrentPrice != item.StartPrice || item.BidCount != 0 {
+		t.Errorf("got current %d start %d bids %d, want an auction without bids", item.CurrentPrice, item.StartPrice, item.BidCount)
+	}
+}
+
+func TestYahooCategoryScraper_extractCategoryItems_noResults(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{
+			name: "no results marker",
+			html: `<div class="Result__header"></div><div class="Notice"><p class="Notice__text">条件に一致する商品は見つかりませんでした。該当する商品がありません。</p></div>`,
+			want: true,
+		},
+		{
+			name: "unrecognized page",
+			html: `<div class="Products__list"><ul class="Products__items"><li class="Item">changed markup</li></ul></div>`,
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to parse html: %v", err)
+			}
+			page, err := (&yahooCategoryScraper{}).extractCategoryItems(doc, 0, CategoryItemsPerPage)
+			if err != nil {
+				t.Fatalf("extractCategoryItems failed: %v", err)
+			}
+			if len(page.Items) != 0 || page.NoResults != tc.want {
+				t.Errorf("got %d items, NoResults %t; want 0 items, NoResults %t", len(page.Items), page.NoResults, tc.want)
+			}
+		})
+	}
+}
+
+func TestParsePostage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in     string
+		want   int64
+		wantOK bool
+	}{
+		{in: "＋送料800円", want: 800, wantOK: true},
+		{in: "+送料1,200円", want: 1200, wantOK: true},
+		{in: "送料無料", want: 0, wantOK: true},
+		{in: "着払い", wantOK: false},
+		{in: "", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parsePostage(tc.in)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("parsePostage(%q) got (%d, %v), want (%d, %v)", tc.in, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestParseShippingPayer(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want model.ShippingPayer
+	}{
+		{in: "送料無料", want: model.ShippingPayerSeller},
+		{in: "送料出品者負担", want: model.ShippingPayerSeller},
+		{in: "落札者負担", want: model.ShippingPayerBuyer},
+		{in: "着払い", want: model.ShippingPayerBuyer},
+		{in: "＋送料800円", want: model.ShippingPayerBuyer},
+		{in: "", want: model.ShippingPayerUnknown},
+		{in: "送料未定", want: model.ShippingPayerUnknown},
+	}
+
+	for _, tc := range cases {
+		if got := parseShippingPayer(tc.in); got != tc.want {
+			t.Errorf("parseShippingPayer(%q) got %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestYahooCategoryScraper_buildCategoryURL(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		page      int64
+		opts      model.CategorySearchOptions
+		wantQuery map[string]string
+		wantNoP   bool
+		wantNoVe  bool
+		wantNoPF  bool
+	}{
+		{
+			name:      "first page without keyword",
+			page:      0,
+			wantQuery: map[string]string{"auccat": "2084261685", "b": "1", "n": "50"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "second page",
+			page:      1,
+			wantQuery: map[string]string{"b": "51"},
+			wantNoP:   true,
+		},
+		{
+			name:      "keyword",
+			page:      0,
+			opts:      model.CategorySearchOptions{Keyword: "ニコン F3"},
+			wantQuery: map[string]string{"p": "ニコン F3", "auccat": "2084261685"},
+			wantNoVe:  true,
+		},
+		{
+			name:      "free shipping only",
+			page:      0,
+			opts:      model.CategorySearchOptions{FreeShippingOnly: true},
+			wantQuery: map[string]string{"pstagefree": "1", "is_postage_mode": "1", "dest_pref_code": "27"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "newly listed within 24 hours",
+			page:      0,
+			opts:      model.CategorySearchOptions{NewlyListedWithin: 24 * time.Hour},
+			wantQuery: map[string]string{"new": "1"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "newly listed within 3 hours",
+			page:      0,
+			opts:      model.CategorySearchOptions{NewlyListedWithin: 3 * time.Hour},
+			wantQuery: map[string]string{"new": "1"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "newly listed beyond supported window",
+			page:      0,
+			opts:      model.CategorySearchOptions{NewlyListedWithin: 48 * time.Hour},
+			wantQuery: map[string]string{"new": ""},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "ending soon",
+			page:      0,
+			opts:      model.CategorySearchOptions{EndingSoon: true},
+			wantQuery: map[string]string{"s1": "end", "o1": "a"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "default sort",
+			page:      0,
+			wantQuery: map[string]string{"s1": "new", "o1": "d", "new": ""},
+			wantNoP:   true,
+			wantNoVe:  true,
+			wantNoPF:  true,
+		},
+		{
+			name:      "exclude keywords",
+			page:      0,
+			opts:      model.CategorySearchOptions{ExcludeKeywords: []string{"ジャンク", "部品取り"}},
+			wantQuery: map[string]string{"ve": "ジャンク 部品取り"},
+			wantNoP:   true,
+		},
+		{
+			name:      "seller type any",
+			page:      0,
+			opts:      model.CategorySearchOptions{SellerType: model.SellerTypeAny},
+			wantQuery: map[string]string{"abatch": ""},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "seller type store",
+			page:      0,
+			opts:      model.CategorySearchOptions{SellerType: model.SellerTypeStore},
+			wantQuery: map[string]string{"abatch": "1"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:      "seller type individual",
+			page:      0,
+			opts:      model.CategorySearchOptions{SellerType: model.SellerTypeIndividual},
+			wantQuery: map[string]string{"abatch": "2"},
+			wantNoP:   true,
+			wantNoVe:  true,
+		},
+		{
+			name:     "empty exclude keywords",
+			page:     0,
+			opts:     model.CategorySearchOptions{ExcludeKeywords: []string{}},
+			wantNoP:  true,
+			wantNoVe: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &yahooCategoryScraper{baseURL: "https://auctions.yahoo.co.jp"}
+			got, err := s.buildCategoryURL("2084261685", tc.page*CategoryItemsPerPage, CategoryItemsPerPage, tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			u, err := url.Parse(got)
+			if err != nil {
+				t.Fatalf("failed to parse url %q: %v", got, err)
+			}
+			if u.Path != "/category/list/2084261685/" {
+				t.Errorf("path got %q, want %q", u.Path, "/category/list/2084261685/")
+			}
+
+			q := u.Query()
+			for k, want := range tc.wantQuery {
+				if q.Get(k) != want {
+					t.Errorf("query %s got %q, want %q", k, q.Get(k), want)
+				}
+			}
+			if tc.wantNoP && q.Has("p") {
+				t.Errorf("query p should be omitted, got %q", q.Get("p"))
+			}
+			if tc.wantNoVe && q.Has("ve") {
+				t.Errorf("query ve should be omitted, got %q", q.Get("ve"))
+			}
+			if tc.wantNoPF && q.Has("pstagefree") {
+				t.Errorf("query pstagefree should be omitted, got %q", q.Get("pstagefree"))
+			}
+		})
+	}
 }
 
-func TestYahooCategoryScraper_URLConstruction(t *testing.T) {
-	// 実際にリクエストは飛ばさず、ロジックだけ確認したいが、
-	// FetchByCategoryはfetchHTMLを呼ぶためユニットテストしづらい。
-	// ここでは省略するが、必要ならFetchHTMLをinterface経由にするなどのリファクタリングが必要。
+func TestNewYahooCategoryScraper_withBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotCat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotCat = r.URL.Query().Get("auccat")
+		_, _ = w.Write([]byte(`<html><body><div class="Products__list"><ul class="Products__items">
+			<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="a1">item</a></h3></li>
+		</ul></div></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooCategoryScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	page, err := repo.FetchByCategory(context.Background(), "2084261685", 0, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/category/list/2084261685/" {
+		t.Errorf("path got %q, want %q", gotPath, "/category/list/2084261685/")
+	}
+	if gotCat != "2084261685" {
+		t.Errorf("auccat got %q, want %q", gotCat, "2084261685")
+	}
+	if len(page.Items) != 1 || page.Items[0].AuctionID != "a1" {
+		t.Errorf("Items got %+v", page.Items)
+	}
+}
+
+func TestYahooCategoryScraper_FetchByCategoryOffset(t *testing.T) {
+	t.Parallel()
+
+	var gotB, gotN string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotB = r.URL.Query().Get("b")
+		gotN = r.URL.Query().Get("n")
+		_, _ = w.Write([]byte(`<html><body></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooCategoryScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	if _, err := repo.FetchByCategoryOffset(context.Background(), "2084261685", 120, 20, model.CategorySearchOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotB != "121" || gotN != "20" {
+		t.Errorf("query got b=%q n=%q, want b=%q n=%q", gotB, gotN, "121", "20")
+	}
+}
+
+func TestYahooCategoryScraper_FetchByCategory_rank(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := r.URL.Query().Get("b")
+		_, _ = fmt.Fprintf(w, `<html><body><div class="Products__list"><ul class="Products__items">
+<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="%[1]s-1">1</a></h3></li>
+<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="%[1]s-2">2</a></h3></li>
+</ul></div></body></html>`, b)
+	}))
+	defer srv.Close()
+
+	repo := NewYahooCategoryScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	for page, want := range map[int64][]int{0: {1, 2}, 1: {int(CategoryItemsPerPage) + 1, int(CategoryItemsPerPage) + 2}} {
+		got, err := repo.FetchByCategory(context.Background(), "2084261685", page, model.CategorySearchOptions{})
+		if err != nil {
+			t.Fatalf("page %d: unexpected error: %v", page, err)
+		}
+		if len(got.Items) != len(want) {
+			t.Fatalf("page %d: Items len got %d, want %d", page, len(got.Items), len(want))
+		}
+		for i, item := range got.Items {
+			if item.Rank != want[i] {
+				t.Errorf("page %d: %s Rank got %d, want %d", page, item.AuctionID, item.Rank, want[i])
+			}
+		}
+	}
+}
+
+func TestYahooCategoryScraper_FetchByCategoryOffset_invalidArguments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		offset int64
+		limit  int64
+	}{
+		{name: "negative offset", offset: -1, limit: 50},
+		{name: "unsupported limit", offset: 0, limit: 30},
+		{name: "zero limit", offset: 0, limit: 0},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// 検証で失敗するためリクエストは送信されない
+			repo := NewYahooCategoryScraper(WithBaseURL("http://127.0.0.1:0"))
+			if _, err := repo.FetchByCategoryOffset(context.Background(), "2084261685", tc.offset, tc.limit, model.CategorySearchOptions{}); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func TestVerifiedHasNext(t *testing.T) {
+	t.Parallel()
+
+	items := func(n int) []*model.CategoryItem {
+		return make([]*model.CategoryItem, n)
+	}
+
+	cases := []struct {
+		name   string
+		offset int64
+		page   *model.CategoryItemsPage
+		want   bool
+	}{
+		{name: "full page at end of results", offset: 50, page: &model.CategoryItemsPage{Items: items(50), TotalCount: 100, HasNext: true}, want: false},
+		{name: "short page with more results", offset: 0, page: &model.CategoryItemsPage{Items: items(48), TotalCount: 120, HasNext: false}, want: true},
+		{name: "last partial page", offset: 100, page: &model.CategoryItemsPage{Items: items(20), TotalCount: 120, HasNext: false}, want: false},
+		{name: "unknown total falls back to heuristic", offset: 0, page: &model.CategoryItemsPage{Items: items(50), HasNext: true}, want: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := verifiedHasNext("2084005", tc.offset, tc.page); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooCategoryScraper_extractCategoryItems_endTimeFromRelativeTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 29, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	html := `<div class="Products__list"><ul class="Products__items">` +
+		`<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="a1">Item</a></h3>` +
+		`<span class="Product__time">残り 15分</span></li>` +
+		`</ul></div>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	s := &yahooCategoryScraper{now: func() time.Time { return now }}
+	page, err := s.extractCategoryItems(doc, 0, CategoryItemsPerPage)
+	if err != nil {
+		t.Fatalf("extractCategoryItems failed: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("items got %d, want 1", len(page.Items))
+	}
+	if want := now.Add(15 * time.Minute); !page.Items[0].EndTime.Equal(want) {
+		t.Errorf("EndTime got %v, want %v", page.Items[0].EndTime, want)
+	}
+}
+
+func TestYahooCategoryScraper_FetchItemCount(t *testing.T) {
+	t.Parallel()
+
+	var gotN string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotN = r.URL.Query().Get("n")
+		_, _ = w.Write([]byte(`<html><body><div class="Result__header"><div class="SearchMode"><div class="Tab"><ul>` +
+			`<li class="Tab__item Tab__item--current"><div><span class="Tab__subText">12,345件</span></div></li>` +
+			`</ul></div></div></div></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooCategoryScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	got, err := repo.FetchItemCount(context.Background(), "2084261685")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 12345 {
+		t.Errorf("count got %d, want 12345", got)
+	}
+	if gotN != "20" {
+		t.Errorf("query n got %q, want %q", gotN, "20")
+	}
 }
@@ -0,0 +1,93 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+// challengeMarkers はアクセス制限の確認（CAPTCHA）ページと判定するための文言です
+var challengeMarkers = []string{"画像認証", "セキュリティ確認", "ロボットではありません"}
+
+// challengeSelectors はCAPTCHAのウィジェットと判定するための要素です
+const challengeSelectors = `.g-recaptcha, .h-captcha, iframe[src*="captcha"], form[action*="captcha"]`
+
+// Challenge は取得時に検出したCAPTCHAなどの確認ページです
+type Challenge struct {
+	URL      string
+	Header   http.Header       // 確認ページのレスポンスヘッダー
+	Document *goquery.Document // 確認ページのHTML
+}
+
+// ChallengeSolution は確認を通過した結果です。再試行するリクエストに付与されます
+type ChallengeSolution struct {
+	Cookies []*http.Cookie
+	Header  http.Header // トークンなど、追加で送信するヘッダー
+}
+
+// ChallengeSolver は確認ページを通過するための処理です
+// 外部の解決サービスとの連携などは利用側で実装します
+type ChallengeSolver interface {
+	Solve(ctx context.Context, c *Challenge) (*ChallengeSolution, error)
+}
+
+// ChallengeSolverFunc は関数を ChallengeSolver として扱うための型です
+type ChallengeSolverFunc func(ctx context.Context, c *Challenge) (*ChallengeSolution, error)
+
+// Solve は f(ctx, c) を呼び出します
+func (f ChallengeSolverFunc) Solve(ctx context.Context, c *Challenge) (*ChallengeSolution, error) {
+	return f(ctx, c)
+}
+
+// challengeError は確認ページを検出した場合のエラーです。repository.ErrChallengeRequired をラップします
+type challengeError struct {
+	challenge *Challenge
+}
+
+func (e *challengeError) Error() string {
+	return fmt.Sprintf("challenge page returned for %s: %v", e.challenge.URL, repository.ErrChallengeRequired)
+}
+
+func (e *challengeError) Unwrap() error {
+	return repository.ErrChallengeRequired
+}
+
+// isChallengePage はHTMLがCAPTCHAなどの確認ページかどうかを判定します
+// 商品説明などに含まれる文言での誤判定を避けるため、文言はタイトルと見出しのみを対象とします
+func isChallengePage(doc *goquery.Document) bool {
+	if doc.Find(challengeSelectors).Length() > 0 {
+		return true
+	}
+
+	texts := []string{doc.Find("title").Text()}
+	doc.Find("h1, h2").Each(func(_ int, s *goquery.Selection) {
+		texts = append(texts, s.Text())
+	})
+	for _, text := range texts {
+		for _, marker := range challengeMarkers {
+			if strings.Contains(text, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apply は確認の通過結果をリクエストに付与します
+func (s *ChallengeSolution) apply(req *http.Request) {
+	if s == nil {
+		return
+	}
+	for _, c := range s.Cookies {
+		req.AddCookie(c)
+	}
+	for key, values := range s.Header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+}
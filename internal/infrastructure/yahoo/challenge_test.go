@@ -0,0 +1,110 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+const challengeHTML = `<html><head><title>画像認証</title></head><body><div class="g-recaptcha" data-sitekey="k"></div></body></html>`
+
+func TestIsChallengePage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{name: "title", html: `<html><head><title>セキュリティ確認 - Yahoo!オークション</title></head></html>`, want: true},
+		{name: "captcha widget", html: `<html><body><div class="h-captcha"></div></body></html>`, want: true},
+		{name: "captcha iframe", html: `<html><body><iframe src="https://example.com/captcha/v2"></iframe></body></html>`, want: true},
+		{name: "marker in description", html: `<html><head><title>商品</title></head><body><p>画像認証は不要です</p></body></html>`, want: false},
+		{name: "normal page", html: `<html><head><title>商品</title></head><body><h1>商品名</h1></body></html>`, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+			if got := isChallengePage(doc); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_challenge(t *testing.T) {
+	t.Parallel()
+
+	solved := ChallengeSolverFunc(func(ctx context.Context, c *Challenge) (*ChallengeSolution, error) {
+		if c.Document.Find(".g-recaptcha").AttrOr("data-sitekey", "") != "k" {
+			t.Errorf("challenge document was not passed to solver")
+		}
+		return &ChallengeSolution{Cookies: []*http.Cookie{{Name: "token", Value: "ok"}}}, nil
+	})
+	failing := ChallengeSolverFunc(func(ctx context.Context, c *Challenge) (*ChallengeSolution, error) {
+		return nil, errors.New("solver unavailable")
+	})
+	wrongToken := ChallengeSolverFunc(func(ctx context.Context, c *Challenge) (*ChallengeSolution, error) {
+		return &ChallengeSolution{Header: http.Header{"X-Token": []string{"ng"}}}, nil
+	})
+
+	cases := []struct {
+		name     string
+		solver   ChallengeSolver
+		wantErr  error
+		wantReqs int
+	}{
+		{name: "no solver", wantErr: repository.ErrChallengeRequired, wantReqs: 1},
+		{name: "solved", solver: solved, wantReqs: 2},
+		{name: "solver error", solver: failing, wantErr: repository.ErrChallengeRequired, wantReqs: 1},
+		{name: "still challenged", solver: wrongToken, wantErr: repository.ErrChallengeRequired, wantReqs: 2},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reqs int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqs++
+				if c, err := r.Cookie("token"); err == nil && c.Value == "ok" {
+					_, _ = w.Write([]byte(`<html><head><title>商品</title></head></html>`))
+					return
+				}
+				_, _ = w.Write([]byte(challengeHTML))
+			}))
+			defer srv.Close()
+
+			doc, err := fetchHTML(context.Background(), srv.Client(), srv.URL, defaultRetryPolicy(), nil, tc.solver)
+			if reqs != tc.wantReqs {
+				t.Errorf("requests got %d, want %d", reqs, tc.wantReqs)
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := doc.Find("title").Text(); got != "商品" {
+				t.Errorf("title got %q, want %q", got, "商品")
+			}
+		})
+	}
+}
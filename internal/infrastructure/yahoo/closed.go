@@ -0,0 +1,106 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// defaultClosedItemBaseURL は終了済みオークションのページのデフォルトのベースURLです
+const defaultClosedItemBaseURL = "https://closedsearch.auctions.yahoo.co.jp"
+
+// errClosedItemNotFound は終了済みオークションのページから商品情報を抽出できなかった場合のエラーです
+var errClosedItemNotFound = errors.New("item not found in closed auction page")
+
+// jst は終了済みオークションのページに表示される日時のタイムゾーンです
+var jst = time.FixedZone("JST", 9*60*60)
+
+// fetchClosedItem は終了済みオークションのページから商品情報を取得します
+// 通常の商品詳細ページが 404 / 410 を返した場合のフォールバックとして利用します
+func (s *yahooScraper) fetchClosedItem(ctx context.Context, auctionID string, fields model.ItemFields) (item *model.Item, err error) {
+	url := fmt.Sprintf("%s/jp/auction/%s", s.closedBaseURL, auctionID)
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchClosedItem", attrAuctionID.String(auctionID), attrURL.String(url))
+	defer func() { endSpan(span, err) }()
+
+	doc, err := fetchHTML(ctx, s.client, url, s.retry, s.validators, s.solver)
+	if err != nil {
+		return nil, err
+	}
+	dumpDocument(s.debugDumpDir, "auction_closed", auctionID, doc, time.Now())
+
+	// 通常のページと同じJSONが埋め込まれていれば、同じ抽出処理を利用する
+	if nextData, err := ParseNextData(doc); err == nil && nextData.HasDetailItem() {
+		return s.extractItemInfo(ctx, doc, auctionID, fields)
+	}
+	return s.extractClosedItemInfo(doc, auctionID, fields)
+}
+
+// extractClosedItemInfo は終了済みオークションのページの表から、タイトル・落札価格・終了日時を抽出します
+// ページに表示されるのは一部の情報のみのため、その他のフィールドはゼロ値となります
+func (s *yahooScraper) extractClosedItemInfo(doc *goquery.Document, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	title := ogTitle(doc)
+	if title == "" {
+		title = strings.TrimSpace(doc.Find("h1").First().Text())
+	}
+	if title == "" {
+		return nil, errClosedItemNotFound
+	}
+
+	finalPrice := parsePrice(otherInfoValue(doc, "落札価格"))
+	item := &model.Item{
+		AuctionID:    auctionID,
+		Title:        title,
+		CurrentPrice: finalPrice,
+		FinalPrice:   finalPrice,
+		Status:       model.StatusFinished,
+		Thumbnail:    ogImage(doc),
+		AuctionInfo:  &model.AuctionInformation{AuctionID: auctionID},
+	}
+	if t, ok := parseJapaneseDateTime(otherInfoValue(doc, "終了日時")); ok {
+		item.AuctionInfo.EndTime = t
+	}
+	if fields.Has(model.ItemFieldImages) {
+		item.Images = []string{}
+		if item.Thumbnail != "" {
+			item.Images = append(item.Images, item.Thumbnail)
+		}
+	}
+	if s.normalizeText {
+		applyTextNormalization(item)
+	}
+	return item, nil
+}
+
+// japaneseDateTimePattern は "2024年1月2日（火）21時3分" や "2024.01.02（火）21:03" 形式の日時にマッチします
+var japaneseDateTimePattern = regexp.MustCompile(`(\d{4})[年./-](\d{1,2})[月./-](\d{1,2})日?\D*?(\d{1,2})[時:](\d{1,2})`)
+
+// parseJapaneseDateTime は終了済みオークションのページに表示される日本時間の日時をパースします
+// ISO 8601 形式の場合はそのままパースします
+func parseJapaneseDateTime(s string) (time.Time, bool) {
+	s = normalizeDigits(s)
+	if t, err := parseDateTime(s); err == nil {
+		return t, true
+	}
+
+	m := japaneseDateTimePattern.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	var parts [5]int
+	for i := range parts {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		parts[i] = n
+	}
+	return time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], 0, 0, jst), true
+}
@@ -0,0 +1,155 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestYahooScraper_FetchByID_closedFallback(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		liveStatus     int
+		closedBody     string
+		wantTitle      string
+		wantFinalPrice int64
+		wantEndTime    time.Time
+		wantErr        bool
+	}{
+		{
+			name:           "archive markup",
+			liveStatus:     http.StatusNotFound,
+			closedBody:     `<html><head><meta property="og:title" content="closed title"></head><body><table><tr><th>落札価格</th><td>12,345円（税込）</td></tr><tr><th>終了日時</th><td>2024年1月2日（火）21時03分</td></tr></table></body></html>`,
+			wantTitle:      "closed title",
+			wantFinalPrice: 12345,
+			wantEndTime:    time.Date(2024, 1, 2, 21, 3, 0, 0, jst),
+		},
+		{
+			name:           "archive next data",
+			liveStatus:     http.StatusGone,
+			closedBody:     `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"json title","price":5000,"status":"closed","endTime":"2024-01-02T21:03:00+09:00"}}}}}}}</script></head></html>`,
+			wantTitle:      "json title",
+			wantFinalPrice: 5000,
+			wantEndTime:    time.Date(2024, 1, 2, 21, 3, 0, 0, jst),
+		},
+		{
+			name:       "archive also missing",
+			liveStatus: http.StatusNotFound,
+			closedBody: `<html><body></body></html>`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/closed/") {
+					_, _ = w.Write([]byte(tc.closedBody))
+					return
+				}
+				w.WriteHeader(tc.liveStatus)
+			}))
+			defer srv.Close()
+
+			o := newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithClosedAuctionFallback()})
+			o.closedBaseURL = srv.URL + "/closed"
+
+			got, err := newYahooScraper(o).FetchByID(context.Background(), "x1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Title != tc.wantTitle {
+				t.Errorf("Title got %q, want %q", got.Title, tc.wantTitle)
+			}
+			if got.Status != model.StatusFinished {
+				t.Errorf("Status got %v, want %v", got.Status, model.StatusFinished)
+			}
+			if got.FinalPrice != tc.wantFinalPrice {
+				t.Errorf("FinalPrice got %d, want %d", got.FinalPrice, tc.wantFinalPrice)
+			}
+			if !got.AuctionInfo.EndTime.Equal(tc.wantEndTime) {
+				t.Errorf("EndTime got %v, want %v", got.AuctionInfo.EndTime, tc.wantEndTime)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_FetchByID_closedFallbackNotUsed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		opts       []Option
+		liveStatus int
+	}{
+		{name: "disabled", liveStatus: http.StatusNotFound},
+		{name: "not a missing page", opts: []Option{WithClosedAuctionFallback()}, liveStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var closedCalled atomic.Bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/closed/") {
+					closedCalled.Store(true)
+				}
+				w.WriteHeader(tc.liveStatus)
+			}))
+			defer srv.Close()
+
+			o := newOptions(srv.URL, append([]Option{WithHTTPClient(srv.Client())}, tc.opts...))
+			o.closedBaseURL = srv.URL + "/closed"
+
+			if _, err := newYahooScraper(o).FetchByID(context.Background(), "x1"); err == nil {
+				t.Fatal("expected error")
+			}
+			if closedCalled.Load() {
+				t.Error("closed auction page should not be requested")
+			}
+		})
+	}
+}
+
+func TestParseJapaneseDateTime(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in     string
+		want   time.Time
+		wantOK bool
+	}{
+		{in: "2024年1月2日（火）21時03分", want: time.Date(2024, 1, 2, 21, 3, 0, 0, jst), wantOK: true},
+		{in: "2024.01.02（火）21:03", want: time.Date(2024, 1, 2, 21, 3, 0, 0, jst), wantOK: true},
+		{in: "２０２４年１２月３１日 ９時５分", want: time.Date(2024, 12, 31, 9, 5, 0, 0, jst), wantOK: true},
+		{in: "2024-01-02T21:03:00+09:00", want: time.Date(2024, 1, 2, 21, 3, 0, 0, jst), wantOK: true},
+		{in: "終了", wantOK: false},
+		{in: "", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseJapaneseDateTime(tc.in)
+		if ok != tc.wantOK || !got.Equal(tc.want) {
+			t.Errorf("parseJapaneseDateTime(%q) got (%v, %v), want (%v, %v)", tc.in, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
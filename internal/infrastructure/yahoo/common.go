@@ -1,19 +1,134 @@
 package yahoo
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/unicode/norm"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
 )
 
+// maintenanceMarkers はメンテナンスページと判定するための文言です
+var maintenanceMarkers = []string{"メンテナンス中", "システムメンテナンス"}
+
 // fetchHTML は指定されたURLからHTMLを取得してgoquery.Documentを返します
 // 共通のUser-Agent設定やエラーハンドリングを行います
-func fetchHTML(ctx context.Context, client *http.Client, url string) (*goquery.Document, error) {
+// 429 応答に Retry-After がある場合は、policy に従って待機してから再試行します
+// validators が nil でない場合は条件付きリクエストを送信し、304 応答では前回の本文を再利用します
+// 確認ページ（CAPTCHA）が返された場合、solver が nil でなければ通過の結果を付けて1回だけ再試行します
+// solver が nil の場合や再試行しても確認ページの場合は repository.ErrChallengeRequired をラップしたエラーを返します
+func fetchHTML(ctx context.Context, client *http.Client, url string, policy retryPolicy, validators *validatorCache, solver ChallengeSolver) (*goquery.Document, error) {
+	doc, err := fetchPage(ctx, client, url, policy, validators, nil)
+	var ce *challengeError
+	if solver == nil || !errors.As(err, &ce) {
+		return doc, err
+	}
+
+	solution, err := solver.Solve(ctx, ce.challenge)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to solve challenge: %w", repository.ErrChallengeRequired, err)
+	}
+	return fetchPage(ctx, client, url, policy, validators, solution)
+}
+
+// fetchPage は fetchHTML の1回分の取得です。solution が nil でない場合はリクエストに付与します
+func fetchPage(ctx context.Context, client *http.Client, url string, policy retryPolicy, validators *validatorCache, solution *ChallengeSolution) (*goquery.Document, error) {
+	var (
+		res *http.Response
+		err error
+	)
+	for attempt := 0; ; attempt++ {
+		res, err = doFetch(ctx, client, url, validators, solution)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= policy.maxRetries {
+			break
+		}
+
+		wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+		closeBody(res)
+		if !ok {
+			return nil, fmt.Errorf("failed to fetch page: status %d: %w", http.StatusTooManyRequests, repository.ErrRateLimited)
+		}
+		if err := sleepContext(ctx, policy.capWait(wait)); err != nil {
+			return nil, fmt.Errorf("failed to wait for retry: %w", err)
+		}
+	}
+	defer closeBody(res)
+
+	// 304 の場合は前回取得した本文をそのまま利用する
+	if res.StatusCode == http.StatusNotModified {
+		if body, ok := validators.cachedBody(url); ok {
+			doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cached HTML: %w", err)
+			}
+			return doc, nil
+		}
+	}
+
+	if res.StatusCode == http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("failed to fetch page: status %d: %w", res.StatusCode, repository.ErrServiceUnavailable)
+	}
+	// 404 / 410 は終了済みオークションのアーカイブページへのフォールバックの判定にも利用する
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("failed to fetch page: status %d: %w", res.StatusCode, repository.ErrNotFound)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("failed to fetch page: status %d: %w", res.StatusCode, repository.ErrRateLimited)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch page: status %d: %w", res.StatusCode, repository.ErrUnexpectedStatus)
+	}
+
+	// Shift_JIS などで配信された場合も文字化けしないよう、UTF-8 に変換してからパースする
+	body, err := utf8Body(res)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	if validators != nil {
+		// 次回の 304 応答に備えて変換後の本文を保持する
+		raw, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	// メンテナンス中は HTTP 200 で汎用ページが返されるため、本文から判定する
+	if isMaintenancePage(doc) {
+		return nil, fmt.Errorf("yahoo auctions is under maintenance: %w", repository.ErrServiceUnavailable)
+	}
+	// 確認ページも HTTP 200 で返されるため、本文から判定する。確認ページは 304 用に保持しない
+	if isChallengePage(doc) {
+		return nil, &challengeError{challenge: &Challenge{URL: url, Header: res.Header.Clone(), Document: doc}}
+	}
+
+	validators.store(url, res.Header, raw)
+	return doc, nil
+}
+
+// doFetch はブラウザ相当のヘッダーを付けて1回だけリクエストを送信します
+func doFetch(ctx context.Context, client *http.Client, url string, validators *validatorCache, solution *ChallengeSolution) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -23,46 +138,147 @@ func fetchHTML(ctx context.Context, client *http.Client, url string) (*goquery.D
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "ja,en-US;q=0.9,en;q=0.8")
+	validators.setConditionalHeaders(req, url)
+	solution.apply(req)
 
 	res, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch page: %w", err)
+		return nil, fmt.Errorf("failed to fetch page: %w: %w", repository.ErrFetchFailed, err)
 	}
-	defer func() {
-		if closeErr := res.Body.Close(); closeErr != nil {
-			fmt.Printf("warning: failed to close response body: %v\n", closeErr)
-		}
-	}()
+	// 呼び出し元のスパン（FetchByID など）にステータスコードを記録する
+	trace.SpanFromContext(ctx).SetAttributes(attrStatusCode.Int(res.StatusCode))
+	return res, nil
+}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch page: status %d", res.StatusCode)
+// utf8Body はレスポンスボディを UTF-8 に変換する Reader を返します
+// 文字コードは Content-Type ヘッダー、BOM、meta タグの順に判定します
+func utf8Body(res *http.Response) (io.Reader, error) {
+	body, err := charset.NewReader(res.Body, res.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode body charset: %w", err)
 	}
+	return body, nil
+}
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+// closeBody はレスポンスボディを閉じます。失敗した場合は警告をログに出力します
+// 本文は読み終えているため、取得自体は失敗として扱いません
+func closeBody(res *http.Response) {
+	if closeErr := res.Body.Close(); closeErr != nil {
+		log.Printf("warning: failed to close response body: %v", closeErr)
 	}
+}
 
-	return doc, nil
+// isMaintenancePage はHTMLがメンテナンス告知ページかどうかを判定します
+// 商品説明などに含まれる文言での誤判定を避けるため、タイトルと見出しのみを対象とします
+func isMaintenancePage(doc *goquery.Document) bool {
+	texts := []string{doc.Find("title").Text()}
+	doc.Find("h1, h2").Each(func(_ int, s *goquery.Selection) {
+		texts = append(texts, s.Text())
+	})
+
+	for _, text := range texts {
+		for _, marker := range maintenanceMarkers {
+			if strings.Contains(text, marker) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// parsePrice は "1,000円" などの文字列から数値を抽出します
-func parsePrice(s string) int64 {
-	// 数字のみ抽出
-	re := regexp.MustCompile(`[0-9]+`)
-	matches := re.FindAllString(s, -1)
-	if len(matches) == 0 {
-		return 0
+// widthReplacer は数値・日時の表記に現れる全角文字を半角に変換します
+// HTMLの &nbsp; などで桁区切りに使われる特殊な空白も、通常の空白に揃えます
+var widthReplacer = strings.NewReplacer(
+	"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+	"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+	"，", ",", "．", ".", "：", ":", "－", "-", "＋", "+", "／", "/", "　", " ",
+	"\u00a0", " ", "\u2009", " ", "\u202f", " ",
+)
+
+// normalizeDigits は全角の数字・記号を半角に変換します
+// "１，２３４円" のように部分的に全角で配信された表記も同じようにパースできるようにします
+func normalizeDigits(s string) string {
+	return widthReplacer.Replace(s)
+}
+
+// parseDateTime は ISO 8601 (RFC 3339) 形式の日時文字列をパースします
+// 全角の数字・記号が混在していても半角に正規化してからパースします
+func parseDateTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(normalizeDigits(s)))
+}
+
+// normalizeText は文字列に NFKC 正規化を適用します
+// 全角英数字は半角に、半角カナは全角になります
+func normalizeText(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// htmlToText はHTML断片からタグを除いたテキストを抽出します
+func htmlToText(html string) string {
+	if html == "" {
+		return ""
 	}
-	// 結合してパース
-	valStr := strings.Join(matches, "")
-	val, err := strconv.ParseInt(valStr, 10, 64)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// priceTokenPattern は価格・件数らしい数値にマッチします
+// 通貨記号（¥/￥/$）、3桁ごとの区切り（カンマまたは空白）、末尾の「円」を含めて判定します
+var priceTokenPattern = regexp.MustCompile(`([¥￥$]?)\s*([0-9]{1,3}(?:[, ][0-9]{3})+|[0-9]+)\s*(円?)`)
+
+// errPriceNotFound は文字列に価格・件数らしい数値が含まれない場合のエラーです
+var errPriceNotFound = errors.New("price not found")
+
+// parsePrice は "1,000円" や "¥1,200" などの文字列から数値を抽出します
+// 商品詳細・カテゴリ一覧・出品者ページのいずれも、価格と件数はこの関数（と parsePriceStrict）でパースします
+// 数値が見つからない場合（"無料" など）やパースできない場合は0を返します。区別が必要な場合は parsePriceStrict を使います
+func parsePrice(s string) int64 {
+	val, err := parsePriceStrict(s)
+	if err != nil {
+		if !errors.Is(err, errPriceNotFound) {
+			log.Printf("warning: failed to parse price %q: %v", s, err)
+		}
 		return 0
 	}
 	return val
 }
 
+// parsePriceStrict は parsePrice と同じ規則で数値を抽出し、失敗した場合はエラーを返します
+// 全角の数字も半角に正規化して扱います
+// 複数の数値を含む場合は ¥ や 円 の付いたものを優先し、なければ最初の数値を返します
+// $ の付いた金額は円ではないため対象外とし、数値が見つからない場合は errPriceNotFound を返します
+// int64 に収まらない数値の場合は strconv.ErrRange をラップしたエラーを返します
+func parsePriceStrict(s string) (int64, error) {
+	s = normalizeDigits(s)
+
+	var candidate string
+	for _, m := range priceTokenPattern.FindAllStringSubmatch(s, -1) {
+		prefix, digits, suffix := m[1], m[2], m[3]
+		if prefix == "$" {
+			continue
+		}
+		if prefix != "" || suffix != "" {
+			candidate = digits
+			break
+		}
+		if candidate == "" {
+			candidate = digits
+		}
+	}
+	if candidate == "" {
+		return 0, fmt.Errorf("%w in %q", errPriceNotFound, s)
+	}
+
+	val, err := strconv.ParseInt(strings.NewReplacer(",", "", " ", "").Replace(candidate), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", candidate, err)
+	}
+	return val, nil
+}
+
 // parseCount は "1,000件" などの文字列から数値を抽出します
 func parseCount(s string) int64 {
 	return parsePrice(s) // 実装は同じでOK
@@ -0,0 +1,359 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/text/encoding/japanese"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+func TestIsMaintenancePage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{
+			name: "maintenance title",
+			html: `<html><head><title>ヤフオク! - メンテナンス中</title></head><body></body></html>`,
+			want: true,
+		},
+		{
+			name: "maintenance heading",
+			html: `<html><head><title>ヤフオク!</title></head><body><h1>ただいまシステムメンテナンスを行っております</h1></body></html>`,
+			want: true,
+		},
+		{
+			name: "marker only in description",
+			html: `<html><head><title>商品</title></head><body><p>メンテナンス中に撮影した写真です</p></body></html>`,
+			want: false,
+		},
+		{
+			name: "normal page",
+			html: `<html><head><title>ヤフオク!</title></head><body><h1>商品一覧</h1></body></html>`,
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+			if got := isMaintenancePage(doc); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_returnsServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "maintenance page with 200", status: http.StatusOK, body: `<html><head><title>メンテナンス中</title></head></html>`},
+		{name: "503", status: http.StatusServiceUnavailable, body: ``},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			_, err := fetchHTML(context.Background(), srv.Client(), srv.URL, defaultRetryPolicy(), nil, nil)
+			if !errors.Is(err, repository.ErrServiceUnavailable) {
+				t.Fatalf("got error %v, want %v", err, repository.ErrServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_wrapsStatusErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "404", status: http.StatusNotFound, wantErr: repository.ErrNotFound},
+		{name: "410", status: http.StatusGone, wantErr: repository.ErrNotFound},
+		{name: "429 without retry-after", status: http.StatusTooManyRequests, wantErr: repository.ErrRateLimited},
+		{name: "500", status: http.StatusInternalServerError, wantErr: repository.ErrUnexpectedStatus},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			_, err := fetchHTML(context.Background(), srv.Client(), srv.URL, defaultRetryPolicy(), nil, nil)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_wrapsTransportError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	_, err := fetchHTML(context.Background(), http.DefaultClient, url, defaultRetryPolicy(), nil, nil)
+	if !errors.Is(err, repository.ErrFetchFailed) {
+		t.Fatalf("got error %v, want %v", err, repository.ErrFetchFailed)
+	}
+}
+
+func TestYahooScraper_FetchByID_unwrapsErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantErr: repository.ErrServiceUnavailable},
+		{name: "next data not found", status: http.StatusOK, body: `<html><body></body></html>`, wantErr: ErrNextDataNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+			_, err := repo.FetchByID(context.Background(), "x1234567890")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePrice_fullWidth(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{in: "1,234円", want: 1234},
+		{in: "１，２３４円", want: 1234},
+		{in: "1，２34円", want: 1234},
+		{in: "０円", want: 0},
+		{in: "", want: 0},
+	}
+
+	for _, tc := range cases {
+		if got := parsePrice(tc.in); got != tc.want {
+			t.Errorf("parsePrice(%q) got %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParsePrice_currencyAndSeparators(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{in: "¥1,200", want: 1200},
+		{in: "￥1,200", want: 1200},
+		{in: "¥ 1,200", want: 1200},
+		{in: "1 200円", want: 1200},
+		{in: "約1,000円", want: 1000},
+		{in: "2個で1,000円", want: 1000},
+		{in: "1,234件", want: 1234},
+		{in: "$12", want: 0},
+		{in: "$12 または 1,500円", want: 1500},
+		{in: "なし", want: 0},
+		{in: "-", want: 0},
+	}
+
+	for _, tc := range cases {
+		if got := parsePrice(tc.in); got != tc.want {
+			t.Errorf("parsePrice(%q) got %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParsePrice_groupingVariants(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{in: "1234567円", want: 1234567},
+		{in: "12345678", want: 12345678},
+		{in: "1,234,567", want: 1234567},
+		{in: "1 234 567円", want: 1234567},
+		{in: "現在 12 345 円", want: 12345},
+		{in: "1\u00a0234円", want: 1234},
+		{in: "1\u202f234円", want: 1234},
+		{in: "1　234円", want: 1234},
+		{in: "１ ２３４円", want: 1234},
+		{in: "3 1,000円", want: 1000},
+		{in: "送料無料", want: 0},
+	}
+
+	for _, tc := range cases {
+		if got := parsePrice(tc.in); got != tc.want {
+			t.Errorf("parsePrice(%q) got %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParsePriceStrict(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{name: "price", in: "1,200円", want: 1200},
+		{name: "zero", in: "0円", want: 0},
+		{name: "no digits", in: "なし", wantErr: errPriceNotFound},
+		{name: "free", in: "無料", wantErr: errPriceNotFound},
+		{name: "overflow", in: "99999999999999999999円", wantErr: strconv.ErrRange},
+		{name: "overflow with separators", in: "99,999,999,999,999,999,999", wantErr: strconv.ErrRange},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parsePriceStrict(tc.in)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				if parsePrice(tc.in) != 0 {
+					t.Errorf("parsePrice(%q) got %d, want 0", tc.in, parsePrice(tc.in))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDateTime_fullWidth(t *testing.T) {
+	t.Parallel()
+
+	want, err := time.Parse(time.RFC3339, "2025-12-29T16:00:10+09:00")
+	if err != nil {
+		t.Fatalf("failed to parse want: %v", err)
+	}
+
+	for _, in := range []string{
+		"2025-12-29T16:00:10+09:00",
+		"２０２５－１２－２９T１６：００：１０＋０９：００",
+		" 2025-12-29T16:00:10+09:00 ",
+	} {
+		got, err := parseDateTime(in)
+		if err != nil {
+			t.Errorf("parseDateTime(%q) returned error: %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseDateTime(%q) got %v, want %v", in, got, want)
+		}
+	}
+
+	if _, err := parseDateTime("not-a-time"); err == nil {
+		t.Errorf("expected error for invalid input")
+	}
+}
+
+func TestFetchHTML_transcodesShiftJIS(t *testing.T) {
+	t.Parallel()
+
+	const title = "ゲーム機 本体 ジャンク"
+
+	cases := []struct {
+		name        string
+		contentType string
+		head        string
+	}{
+		{name: "charset in header", contentType: "text/html; charset=Shift_JIS"},
+		{name: "charset in meta", contentType: "text/html", head: `<meta charset="Shift_JIS">`},
+		{name: "http-equiv meta", contentType: "text/html", head: `<meta http-equiv="Content-Type" content="text/html; charset=Shift_JIS">`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			page := `<html><head>` + tc.head + `<title>` + title + `</title></head><body><h1>` + title + `</h1></body></html>`
+			encoded, err := japanese.ShiftJIS.NewEncoder().String(page)
+			if err != nil {
+				t.Fatalf("failed to encode fixture: %v", err)
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				_, _ = w.Write([]byte(encoded))
+			}))
+			defer srv.Close()
+
+			for _, validators := range []*validatorCache{nil, newValidatorCache()} {
+				doc, err := fetchHTML(context.Background(), srv.Client(), srv.URL, defaultRetryPolicy(), validators, nil)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := doc.Find("h1").Text(); got != title {
+					t.Errorf("h1 got %q, want %q", got, title)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// TestScrapers_concurrentUse は1つのスクレイパーを多数のgoroutineから同時に利用しても、
+// 共有している状態（条件付きリクエストのキャッシュ・リクエスト数の集計）でデータ競合が起きないことを確認します
+// パッケージのドキュメントに記載している、すべてのスクレイパーが並行に利用できることの保証を対象とします
+// データ競合の検出には go test -race で実行する必要があります
+func TestScrapers_concurrentUse(t *testing.T) {
+	t.Parallel()
+
+	const (
+		goroutines = 16
+		iterations = 10
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 2回目以降は 304 を返し、キャッシュした本文の再利用も並行に行われるようにする
+		etag := `"` + r.URL.RequestURI() + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if strings.HasPrefix(r.URL.Path, "/category/") {
+			_, _ = w.Write([]byte(`<html><body><div class="Products__list"><ul class="Products__items">` +
+				`<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="c1">item</a></h3></li>` +
+				`</ul></div></body></html>`))
+			return
+		}
+		if r.URL.Path == "/jp/show/rating" {
+			_, _ = w.Write([]byte(`<html><body><table>` +
+				`<tr><th></th><th>過去6ヶ月</th><th>過去12ヶ月</th><th>全期間</th></tr>` +
+				`<tr><th>良い</th><td>1</td><td>2</td><td>3</td></tr>` +
+				`</table></body></html>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">` +
+			`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title","price":100,"status":"open"}}}}}}}` +
+			`</script></head><body></body></html>`))
+	}))
+	defer srv.Close()
+
+	items := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()), WithConditionalRequests())
+	categories := NewYahooCategoryScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()), WithConditionalRequests())
+	sellers := NewYahooSellerScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()), WithConditionalRequests())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*iterations*3)
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				// 同じURLと異なるURLを混ぜ、キャッシュの読み書きが競合するようにする
+				id := fmt.Sprintf("x%d", (g+i)%4)
+				if item, err := items.FetchByID(context.Background(), id); err != nil {
+					errs <- fmt.Errorf("FetchByID(%s): %w", id, err)
+				} else if item.Title != "title" {
+					errs <- fmt.Errorf("FetchByID(%s): title got %q", id, item.Title)
+				}
+				if page, err := categories.FetchByCategory(context.Background(), "1", int64(i%2), model.CategorySearchOptions{}); err != nil {
+					errs <- fmt.Errorf("FetchByCategory: %w", err)
+				} else if len(page.Items) != 1 {
+					errs <- fmt.Errorf("FetchByCategory: items got %d", len(page.Items))
+				}
+				sellerID := fmt.Sprintf("s%d", (g+i)%4)
+				if rating, err := sellers.FetchSellerRating(context.Background(), sellerID); err != nil {
+					errs <- fmt.Errorf("FetchSellerRating(%s): %w", sellerID, err)
+				} else if rating.Total.Good != 3 {
+					errs <- fmt.Errorf("FetchSellerRating(%s): total good got %d", sellerID, rating.Total.Good)
+				}
+				// 集計値の読み出しも書き込みと並行に行う
+				_ = items.(StatsProvider).Stats()
+				_ = categories.(StatsProvider).Stats()
+				_ = sellers.(StatsProvider).Stats()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	const want = goroutines * iterations
+	for name, repo := range map[string]any{"item": items, "category": categories, "seller": sellers} {
+		if got := repo.(StatsProvider).Stats(); got.Successes != want || got.Failures != 0 {
+			t.Errorf("%s stats got %+v, want %d successes", name, got, want)
+		}
+	}
+}
+
+// TestYahooScraper_requestCoalescing は同じ商品を同時に取得した場合にリクエストが1回にまとめられ、
+// 最初の呼び出し元がキャンセルされても他の呼び出し元は結果を受け取れることを確認します
+func TestYahooScraper_requestCoalescing(t *testing.T) {
+	t.Parallel()
+
+	const waiters = 8
+
+	var requests atomic.Int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"auctionId":"hot","title":"t"}}}}}}}</script></head></html>`))
+	}))
+	defer srv.Close()
+
+	repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithRequestCoalescing()}))
+
+	// 最初の呼び出し元が取得を開始し、レスポンスを待っている間にキャンセルされる
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.FetchByID(firstCtx, "hot")
+		firstErr <- err
+	}()
+	<-arrived
+
+	var wg sync.WaitGroup
+	errs := make(chan error, waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := repo.FetchByID(context.Background(), "hot")
+			if err == nil && item.Title != "t" {
+				err = fmt.Errorf("title got %q, want %q", item.Title, "t")
+			}
+			errs <- err
+		}()
+	}
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller got error %v, want %v", err, context.Canceled)
+	}
+	// 待機中の呼び出し元がまとめられる前にレスポンスを返さないよう、少し待ってから解放する
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("upstream requests got %d, want 1", got)
+	}
+}
+
+// TestYahooScraper_requestCoalescing_independentItems はまとめられた取得の結果を受け取った呼び出し元が
+// 入れ子のフィールド（出品者・画像・関連商品など）を書き換えても、他の呼び出し元の商品情報に影響しないことを確認します
+func TestYahooScraper_requestCoalescing_independentItems(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{` +
+			`"item":{"detail":{"item":{"auctionId":"hot","title":"t","initPrice":100,"tags":["tag"],` +
+			`"seller":{"aucUserId":"s1","displayName":"seller"},"img":[{"image":"https://example.com/1.jpg"}],` +
+			`"questions":[{"question":"q","answer":"a"}],"variations":[{"name":"M","price":100,"stock":1}]}}},` +
+			`"recommend":{"items":[{"auctionId":"r1","title":"related"}]}}}}}</script></head></html>`))
+	}))
+	defer srv.Close()
+
+	repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithRequestCoalescing()}))
+
+	items := make([]*model.Item, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		items[0], errs[0] = repo.FetchByID(context.Background(), "hot")
+	}()
+	<-arrived
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		items[1], errs[1] = repo.FetchByID(context.Background(), "hot")
+	}()
+	// 2つ目の呼び出し元がまとめられる前にレスポンスを返さないよう、少し待ってから解放する
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: unexpected error: %v", i, err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("upstream requests got %d, want 1", got)
+	}
+	a, b := items[0], items[1]
+	if a.Seller == nil || a.AuctionInfo == nil || len(a.Images) == 0 || len(a.Tags) == 0 ||
+		len(a.Questions) == 0 || len(a.Variations) == 0 || len(a.RelatedItems) == 0 {
+		t.Fatalf("fixture did not populate nested fields: %+v", a)
+	}
+
+	a.Seller.Name = "changed"
+	a.AuctionInfo.StartPrice = 1
+	a.Images[0] = "changed"
+	a.Tags[0] = "changed"
+	a.Questions[0].Answer = "changed"
+	a.Variations[0].Stock = 99
+	a.RelatedItems[0].Title = "changed"
+
+	if b.Seller.Name != "seller" {
+		t.Errorf("Seller.Name got %q, want %q", b.Seller.Name, "seller")
+	}
+	if b.AuctionInfo.StartPrice != 100 {
+		t.Errorf("AuctionInfo.StartPrice got %d, want 100", b.AuctionInfo.StartPrice)
+	}
+	if b.Images[0] != "https://example.com/1.jpg" {
+		t.Errorf("Images[0] got %q", b.Images[0])
+	}
+	if b.Tags[0] != "tag" {
+		t.Errorf("Tags[0] got %q, want %q", b.Tags[0], "tag")
+	}
+	if b.Questions[0].Answer != "a" {
+		t.Errorf("Questions[0].Answer got %q, want %q", b.Questions[0].Answer, "a")
+	}
+	if b.Variations[0].Stock != 1 {
+		t.Errorf("Variations[0].Stock got %d, want 1", b.Variations[0].Stock)
+	}
+	if b.RelatedItems[0].Title != "related" {
+		t.Errorf("RelatedItems[0].Title got %q, want %q", b.RelatedItems[0].Title, "related")
+	}
+}
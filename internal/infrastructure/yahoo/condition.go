@@ -0,0 +1,37 @@
+package yahoo
+
+import (
+	"strings"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// conditionPhrases はヤフオクの商品の状態の表記とドメインの Condition の対応です
+// 「未使用に近い」と「未使用」のように部分一致する表記があるため、長い表記から順に判定します
+var conditionPhrases = []struct {
+	phrase    string
+	condition model.Condition
+}{
+	{"全体的に状態が悪い", model.ConditionPoor},
+	{"目立った傷や汚れなし", model.ConditionNoVisibleDamage},
+	{"やや傷や汚れあり", model.ConditionMinorDamage},
+	{"傷や汚れあり", model.ConditionDamaged},
+	{"未使用に近い", model.ConditionLikeNew},
+	{"未使用", model.ConditionNew},
+	{"新品", model.ConditionNew},
+}
+
+// parseCondition は商品の状態の表記を Condition に変換します
+// 「中古」のように程度が分からない表記や未知の表記は ConditionUnspecified を返します
+func parseCondition(text string) model.Condition {
+	text = normalizeText(strings.TrimSpace(text))
+	if text == "" {
+		return model.ConditionUnspecified
+	}
+	for _, p := range conditionPhrases {
+		if strings.Contains(text, p.phrase) {
+			return p.condition
+		}
+	}
+	return model.ConditionUnspecified
+}
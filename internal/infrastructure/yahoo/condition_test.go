@@ -0,0 +1,34 @@
+package yahoo
+
+import (
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestParseCondition(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want model.Condition
+	}{
+		{in: "新品", want: model.ConditionNew},
+		{in: "未使用", want: model.ConditionNew},
+		{in: "未使用に近い", want: model.ConditionLikeNew},
+		{in: "目立った傷や汚れなし", want: model.ConditionNoVisibleDamage},
+		{in: "やや傷や汚れあり", want: model.ConditionMinorDamage},
+		{in: "傷や汚れあり", want: model.ConditionDamaged},
+		{in: "全体的に状態が悪い", want: model.ConditionPoor},
+		{in: " 中古 - やや傷や汚れあり ", want: model.ConditionMinorDamage},
+		{in: "中古", want: model.ConditionUnspecified},
+		{in: "", want: model.ConditionUnspecified},
+		{in: "unknown", want: model.ConditionUnspecified},
+	}
+
+	for _, tc := range cases {
+		if got := parseCondition(tc.in); got != tc.want {
+			t.Errorf("parseCondition(%q) got %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package yahoo
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// dumpTimeLayout はダンプファイル名に付与する時刻の書式です
+const dumpTimeLayout = "20060102T150405.000000000"
+
+// dumpFileNameReplacer はIDに含まれるファイル名として不適切な文字を置き換えます
+var dumpFileNameReplacer = strings.NewReplacer("/", "_", `\`, "_", "..", "_", ":", "_", " ", "_")
+
+// dumpDocument は取得したHTMLと __NEXT_DATA__ のJSONを dir に書き出します
+// ファイル名は {kind}_{id}_{時刻}.html / .json です。デバッグ用途のため、失敗してもログを残すだけでエラーは返しません
+func dumpDocument(dir, kind, id string, doc *goquery.Document, now time.Time) {
+	if dir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Printf("warning: failed to create debug dump dir: %v", err)
+		return
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%s_%s_%s", kind, dumpFileNameReplacer.Replace(id), now.Format(dumpTimeLayout)))
+
+	html, err := doc.Html()
+	if err != nil {
+		log.Printf("warning: failed to render html for debug dump: %v", err)
+		return
+	}
+	if err := os.WriteFile(base+".html", []byte(html), 0o644); err != nil {
+		log.Printf("warning: failed to write debug dump: %v", err)
+		return
+	}
+
+	// __NEXT_DATA__ がない（カテゴリ一覧など）場合はHTMLのみ書き出す
+	if script := doc.Find("script#__NEXT_DATA__"); script.Length() > 0 {
+		if err := os.WriteFile(base+".json", []byte(script.Text()), 0o644); err != nil {
+			log.Printf("warning: failed to write debug dump: %v", err)
+		}
+	}
+}
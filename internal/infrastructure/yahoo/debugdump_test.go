@@ -0,0 +1,96 @@
+package yahoo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestDumpDocument(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		html     string
+		id       string
+		wantJSON bool
+	}{
+		{
+			name:     "with next data",
+			html:     `<html><head><script id="__NEXT_DATA__">{"props":{}}</script></head><body>item</body></html>`,
+			id:       "x1234567890",
+			wantJSON: true,
+		},
+		{
+			name: "without next data",
+			html: `<html><body>list</body></html>`,
+			id:   "../2084005_p0",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			dir := filepath.Join(t.TempDir(), "dump")
+			dumpDocument(dir, "auction", tc.id, doc, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("failed to read dump dir: %v", err)
+			}
+
+			var htmlFound, jsonFound bool
+			for _, e := range entries {
+				name := e.Name()
+				if !strings.HasPrefix(name, "auction_") || !strings.Contains(name, "20260102T030405") {
+					t.Fatalf("unexpected file name: %q", name)
+				}
+				if strings.Contains(name, "/") || strings.Contains(name, "..") {
+					t.Fatalf("file name is not sanitized: %q", name)
+				}
+				switch filepath.Ext(name) {
+				case ".html":
+					htmlFound = true
+				case ".json":
+					jsonFound = true
+					b, err := os.ReadFile(filepath.Join(dir, name))
+					if err != nil {
+						t.Fatalf("failed to read json dump: %v", err)
+					}
+					if string(b) != `{"props":{}}` {
+						t.Fatalf("json dump got %q", string(b))
+					}
+				}
+			}
+			if !htmlFound {
+				t.Fatalf("html dump not written")
+			}
+			if jsonFound != tc.wantJSON {
+				t.Fatalf("json dump written=%v, want %v", jsonFound, tc.wantJSON)
+			}
+		})
+	}
+}
+
+func TestDumpDocument_disabled(t *testing.T) {
+	t.Parallel()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html></html>`))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	// dir が空の場合は何も書き出さない（パニックもしない）
+	dumpDocument("", "auction", "x1", doc, time.Now())
+}
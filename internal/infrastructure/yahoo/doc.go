@@ -0,0 +1,5 @@
+// Package yahoo はヤフオクのページを取得・解析して、ドメイン層のリポジトリを実装します
+//
+// New で始まるコンストラクタが作成するスクレイパーはいずれも複数のgoroutineから同時に利用でき、
+// 条件付きリクエストのキャッシュやリクエスト数の集計値などの内部状態も安全に共有されます
+package yahoo
@@ -0,0 +1,52 @@
+package yahoo
+
+import (
+	"context"
+	"log"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// ImageEnricher は取得した商品の画像URLから、代表色などのメタデータを求めて商品に付与する処理です
+// 画像のデコードなどはこのパッケージでは行わないため、必要なライブラリは利用側で用意します
+// imageURLs はメイン画像（Item.Thumbnail）を先頭に、重複を除いた画像のURLです
+type ImageEnricher interface {
+	Enrich(ctx context.Context, item *model.Item, imageURLs []string) error
+}
+
+// ImageEnricherFunc は関数を ImageEnricher として扱うための型です
+type ImageEnricherFunc func(ctx context.Context, item *model.Item, imageURLs []string) error
+
+// Enrich は f(ctx, item, imageURLs) を呼び出します
+func (f ImageEnricherFunc) Enrich(ctx context.Context, item *model.Item, imageURLs []string) error {
+	return f(ctx, item, imageURLs)
+}
+
+// enrichImages は ImageEnricher が設定されている場合に、商品の画像からメタデータを付与します
+// メタデータは補助的な情報のため、失敗しても警告を出力するだけで商品の取得自体は失敗としません
+func (s *yahooScraper) enrichImages(ctx context.Context, item *model.Item) {
+	if s.imageEnricher == nil {
+		return
+	}
+	urls := imageURLs(item)
+	if len(urls) == 0 {
+		return
+	}
+	if err := s.imageEnricher.Enrich(ctx, item, urls); err != nil {
+		log.Printf("warning: failed to enrich images for %s: %v", item.AuctionID, err)
+	}
+}
+
+// imageURLs はメイン画像を先頭に、重複と空文字列を除いた商品の画像URLを返します
+func imageURLs(item *model.Item) []string {
+	urls := make([]string, 0, len(item.Images)+1)
+	seen := make(map[string]bool)
+	for _, u := range append([]string{item.Thumbnail}, item.Images...) {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+	return urls
+}
@@ -0,0 +1,158 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestYahooScraper_FetchByID_imageEnricher(t *testing.T) {
+	t.Parallel()
+
+	const page = `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t","img":[{"image":"https://example.com/1.jpg"},{"image":"https://example.com/2.jpg","isMain":true}]}}}}}}}</script></head></html>`
+
+	cases := []struct {
+		name      string
+		enrichErr error
+		wantMeta  map[string]string
+	}{
+		{name: "attaches metadata", wantMeta: map[string]string{"dominant_color": "#ff0000"}},
+		{name: "error does not fail fetch", enrichErr: errors.New("decode failed")},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(page))
+			}))
+			defer srv.Close()
+
+			var gotURLs []string
+			enricher := ImageEnricherFunc(func(ctx context.Context, item *model.Item, imageURLs []string) error {
+				gotURLs = imageURLs
+				if tc.enrichErr != nil {
+					return tc.enrichErr
+				}
+				item.ImageMetadata = map[string]string{"dominant_color": "#ff0000"}
+				return nil
+			})
+
+			repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithImageEnricher(enricher)}))
+			got, err := repo.FetchByID(context.Background(), "x1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantURLs := []string{"https://example.com/2.jpg", "https://example.com/1.jpg"}
+			if !reflect.DeepEqual(gotURLs, wantURLs) {
+				t.Errorf("image urls got %v, want %v", gotURLs, wantURLs)
+			}
+			if !reflect.DeepEqual(got.ImageMetadata, tc.wantMeta) {
+				t.Errorf("ImageMetadata got %v, want %v", got.ImageMetadata, tc.wantMeta)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_FetchByIDWithFields_skipsEnricherWithoutImageField(t *testing.T) {
+	t.Parallel()
+
+	const page = `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t","img":[{"image":"https://example.com/1.jpg","isMain":true}]}}}}}}}</script></head></html>`
+
+	// 通常のページ・終了後のページ・モバイル版ページのいずれで取得した場合も、画像を要求しなければ呼び出さない
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		opts    []Option
+	}{
+		{
+			name: "live page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(page))
+			},
+		},
+		{
+			name: "closed fallback",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasPrefix(r.URL.Path, "/closed/") {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(page))
+			},
+			opts: []Option{WithClosedAuctionFallback()},
+		},
+		{
+			name: "mobile fallback",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasPrefix(r.URL.Path, "/m/") {
+					_, _ = w.Write([]byte(`<html><body>desktop markup changed</body></html>`))
+					return
+				}
+				_, _ = w.Write([]byte(page))
+			},
+			opts: []Option{WithMobileFallback()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+
+			var calls atomic.Int32
+			enricher := ImageEnricherFunc(func(ctx context.Context, item *model.Item, imageURLs []string) error {
+				calls.Add(1)
+				return nil
+			})
+
+			o := newOptions(srv.URL, append([]Option{WithHTTPClient(srv.Client()), WithImageEnricher(enricher)}, tc.opts...))
+			o.closedBaseURL = srv.URL + "/closed"
+			o.mobileBaseURL = srv.URL + "/m"
+			repo := newYahooScraper(o)
+
+			if _, err := repo.FetchByIDWithFields(context.Background(), "x1", model.ItemFieldsNone); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := calls.Load(); got != 0 {
+				t.Errorf("enricher calls got %d, want 0 without ItemFieldImages", got)
+			}
+
+			if _, err := repo.FetchByIDWithFields(context.Background(), "x1", model.ItemFieldImages); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := calls.Load(); got != 1 {
+				t.Errorf("enricher calls got %d, want 1 with ItemFieldImages", got)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_enrichImages_skipsWithoutImages(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	s := &yahooScraper{imageEnricher: ImageEnricherFunc(func(ctx context.Context, item *model.Item, imageURLs []string) error {
+		called = true
+		return nil
+	})}
+	s.enrichImages(context.Background(), &model.Item{AuctionID: "x1"})
+	if called {
+		t.Error("enricher should not be called for an item without images")
+	}
+
+	// 未設定の場合は何もしない
+	(&yahooScraper{}).enrichImages(context.Background(), &model.Item{Thumbnail: "https://example.com/1.jpg"})
+}
@@ -0,0 +1,388 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// 商品詳細の抽出パイプラインを構成するフィールドの名前です
+// WithFieldExtractor でこの名前を指定すると、既定の抽出処理を差し替えられます
+const (
+	FieldPrice          = "price"
+	FieldRelatedItems   = "related_items"
+	FieldDescription    = "description"
+	FieldQuestions      = "questions"
+	FieldImages         = "images"
+	FieldSellerLocation = "seller_location"
+	FieldShippingDays   = "shipping_days"
+	FieldShippingSize   = "shipping_size"
+	FieldShippingWeight = "shipping_weight"
+	FieldCondition      = "condition"
+	FieldEndTime        = "end_time"
+
+	FieldShipsInternationally = "ships_internationally"
+	FieldRelisted             = "relisted"
+	FieldItemLocation         = "item_location"
+	FieldWatchCount           = "watch_count"
+	FieldViewingNowCount      = "viewing_now_count"
+)
+
+// ExtractInput はフィールドの抽出処理に渡される入力です
+type ExtractInput struct {
+	AuctionID string
+	Doc       *goquery.Document // 商品詳細ページのHTML
+	NextData  *NextData         // 埋め込まれたJSON。item にはマッピング済みです
+	Fields    model.ItemFields  // 取得対象の任意フィールド
+	Now       time.Time         // 残り時間などの相対表記を解決する基準時刻
+}
+
+// FieldExtractor は埋め込みJSONからのマッピング後に、商品情報の1フィールドを補完する処理です
+// 値を設定した場合は true を返します。true の場合、そのフィールドはHTMLからのフォールバックとして記録されます
+type FieldExtractor interface {
+	Extract(in *ExtractInput, item *model.Item) bool
+}
+
+// FieldExtractorFunc は関数を FieldExtractor として扱うための型です
+type FieldExtractorFunc func(in *ExtractInput, item *model.Item) bool
+
+// Extract は f(in, item) を呼び出します
+func (f FieldExtractorFunc) Extract(in *ExtractInput, item *model.Item) bool {
+	return f(in, item)
+}
+
+// namedExtractor はパイプライン内の抽出処理とそのフィールド名の組です
+type namedExtractor struct {
+	field     string
+	extractor FieldExtractor
+}
+
+// defaultFieldExtractors は既定の抽出パイプラインです。上から順に実行します
+// いずれもJSONに値がない場合のみHTMLから取得します
+var defaultFieldExtractors = []namedExtractor{
+	{FieldPrice, FieldExtractorFunc(extractPriceField)},
+	{FieldRelatedItems, FieldExtractorFunc(extractRelatedItemsField)},
+	{FieldDescription, FieldExtractorFunc(extractDescriptionField)},
+	{FieldQuestions, FieldExtractorFunc(extractQuestionsField)},
+	{FieldImages, FieldExtractorFunc(extractImagesField)},
+	{FieldSellerLocation, FieldExtractorFunc(extractSellerLocationField)},
+	{FieldShippingDays, FieldExtractorFunc(extractShippingDaysField)},
+	{FieldShippingSize, FieldExtractorFunc(extractShippingSizeField)},
+	{FieldShippingWeight, FieldExtractorFunc(extractShippingWeightField)},
+	{FieldCondition, FieldExtractorFunc(extractConditionField)},
+	{FieldEndTime, FieldExtractorFunc(extractEndTimeField)},
+	{FieldShipsInternationally, FieldExtractorFunc(extractShipsInternationallyField)},
+	{FieldRelisted, FieldExtractorFunc(extractRelistedField)},
+	{FieldItemLocation, FieldExtractorFunc(extractItemLocationField)},
+	{FieldWatchCount, FieldExtractorFunc(extractWatchCountField)},
+	{FieldViewingNowCount, FieldExtractorFunc(extractViewingNowCountField)},
+}
+
+// buildFieldExtractors は既定のパイプラインに overrides を適用したパイプラインを返します
+// 既定と同じフィールド名の処理は置き換え、それ以外は末尾に追加します
+func buildFieldExtractors(overrides []namedExtractor) []namedExtractor {
+	pipeline := append([]namedExtractor(nil), defaultFieldExtractors...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range pipeline {
+			if pipeline[i].field == o.field {
+				pipeline[i] = o
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			pipeline = append(pipeline, o)
+		}
+	}
+	return pipeline
+}
+
+// extractPriceField は現在価格がJSONに含まれない場合に、ページのメタデータ（og:price:amount など）や
+// 構造化データ（JSON-LD の offers.price）から取得します。CSSのクラス名よりも変更されにくい情報です
+func extractPriceField(in *ExtractInput, item *model.Item) bool {
+	if item.CurrentPrice > 0 {
+		return false
+	}
+	price, ok := metaPrice(in.Doc)
+	if !ok {
+		price, ok = jsonLDPrice(in.Doc)
+	}
+	if !ok {
+		return false
+	}
+	item.CurrentPrice = price
+	return true
+}
+
+// priceMetaSelectors は価格を表すメタデータのセレクターです。上から順に探します
+var priceMetaSelectors = []string{
+	`meta[property="product:price:amount"]`,
+	`meta[property="og:price:amount"]`,
+	`meta[itemprop="price"]`,
+}
+
+// metaPrice はページのメタデータから価格を返します。見つからない場合は false を返します
+func metaPrice(doc *goquery.Document) (int64, bool) {
+	for _, sel := range priceMetaSelectors {
+		if v, exists := doc.Find(sel).First().Attr("content"); exists {
+			if price, err := parsePriceStrict(v); err == nil && price > 0 {
+				return price, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// jsonLDOffer は JSON-LD（schema.org の Product）の offers のうち、価格の抽出に使う項目です
+// price は数値・文字列のどちらでも記述されるため、文字列として扱います
+type jsonLDOffer struct {
+	Price json.RawMessage `json:"price"`
+}
+
+// jsonLDProduct は JSON-LD の Product のうち、価格の抽出に使う項目です。offers は単一または配列で記述されます
+type jsonLDProduct struct {
+	Offers json.RawMessage `json:"offers"`
+}
+
+// jsonLDPrice はページの JSON-LD から最初に見つかった offers.price を返します。見つからない場合は false を返します
+func jsonLDPrice(doc *goquery.Document) (int64, bool) {
+	var (
+		price int64
+		found bool
+	)
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		for _, product := range decodeOneOrMany[jsonLDProduct]([]byte(s.Text())) {
+			for _, offer := range decodeOneOrMany[jsonLDOffer](product.Offers) {
+				v, err := parsePriceStrict(strings.Trim(string(offer.Price), `"`))
+				if err == nil && v > 0 {
+					price, found = v, true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return price, found
+}
+
+// decodeOneOrMany は単一のオブジェクトまたはその配列として記述されたJSONを配列として返します
+// どちらとしても読めない場合は nil を返します
+func decodeOneOrMany[T any](data []byte) []T {
+	var many []T
+	if err := json.Unmarshal(data, &many); err == nil {
+		return many
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err == nil {
+		return []T{one}
+	}
+	return nil
+}
+
+// extractRelatedItemsField は関連商品がJSONに含まれない場合にHTMLのおすすめ欄から取得します
+func extractRelatedItemsField(in *ExtractInput, item *model.Item) bool {
+	if !in.Fields.Has(model.ItemFieldRelatedItems) || len(item.RelatedItems) > 0 {
+		return false
+	}
+	item.RelatedItems = extractRelatedItemsFromHTML(in.Doc)
+	return len(item.RelatedItems) > 0
+}
+
+// extractDescriptionField は説明文がJSONの descriptionHtml（全文）にない場合のみHTMLの説明欄から取得します
+func extractDescriptionField(in *ExtractInput, item *model.Item) bool {
+	if !in.Fields.Has(model.ItemFieldDescription) || item.Description != "" {
+		return false
+	}
+	desc, truncated := descriptionFromHTML(in.Doc)
+	if desc == "" {
+		return false
+	}
+	item.Description = desc
+	item.DescriptionText = htmlToText(desc)
+	if truncated {
+		log.Printf("warning: description for %s is truncated in html (%s); only the visible part was extracted", in.AuctionID, descriptionTruncationMarker)
+	}
+	return true
+}
+
+// extractQuestionsField は質問と回答がJSONに含まれない場合にHTMLの質問欄から取得します
+func extractQuestionsField(in *ExtractInput, item *model.Item) bool {
+	if !in.Fields.Has(model.ItemFieldQuestions) || len(item.Questions) > 0 {
+		return false
+	}
+	item.Questions = extractQuestionsFromHTML(in.Doc)
+	return len(item.Questions) > 0
+}
+
+// extractImagesField は画像がJSONに含まれない場合に、ページのサムネイル（og:image）を代わりに使います
+func extractImagesField(in *ExtractInput, item *model.Item) bool {
+	if !in.Fields.Has(model.ItemFieldImages) || len(item.Images) > 0 {
+		return false
+	}
+	thumb := ogImage(in.Doc)
+	if thumb == "" {
+		return false
+	}
+	item.Images = []string{thumb}
+	return true
+}
+
+// extractSellerLocationField は発送元の地域がJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractSellerLocationField(in *ExtractInput, item *model.Item) bool {
+	if item.Seller == nil || item.Seller.Location != "" {
+		return false
+	}
+	item.Seller.Location = otherInfoValue(in.Doc, "発送元の地域")
+	return item.Seller.Location != ""
+}
+
+// extractShippingDaysField は発送までの日数がJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractShippingDaysField(in *ExtractInput, item *model.Item) bool {
+	if item.ShippingDays != "" {
+		return false
+	}
+	item.ShippingDays = otherInfoValue(in.Doc, "発送までの日数")
+	return item.ShippingDays != ""
+}
+
+// extractShippingSizeField は荷物のサイズがJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractShippingSizeField(in *ExtractInput, item *model.Item) bool {
+	if item.ShippingSize != "" {
+		return false
+	}
+	item.ShippingSize = parseShippingSize(otherInfoValue(in.Doc, "荷物のサイズ"))
+	return item.ShippingSize != ""
+}
+
+// extractShippingWeightField は荷物の重量がJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractShippingWeightField(in *ExtractInput, item *model.Item) bool {
+	if item.ShippingWeight > 0 {
+		return false
+	}
+	item.ShippingWeight = parseShippingWeight(otherInfoValue(in.Doc, "荷物の重量"))
+	return item.ShippingWeight > 0
+}
+
+// extractConditionField は商品の状態がJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractConditionField(in *ExtractInput, item *model.Item) bool {
+	if item.Condition != model.ConditionUnspecified {
+		return false
+	}
+	c := parseCondition(otherInfoValue(in.Doc, "商品の状態"))
+	if c == model.ConditionUnspecified {
+		return false
+	}
+	item.Condition = c
+	return true
+}
+
+// extractEndTimeField は終了日時がJSONに含まれない場合に、HTMLの残り時間の表記（例: 残り 3時間）から求めます
+func extractEndTimeField(in *ExtractInput, item *model.Item) bool {
+	if item.AuctionInfo == nil || !item.AuctionInfo.EndTime.IsZero() {
+		return false
+	}
+	t, err := parseRelativeTime(otherInfoValue(in.Doc, "残り時間"), in.Now)
+	if err != nil {
+		return false
+	}
+	item.AuctionInfo.EndTime = t
+	return true
+}
+
+// extractShipsInternationallyField は海外発送の可否がJSONで示されていない場合に、HTMLの商品情報欄（海外発送）から判定します
+// 「対応」「可」などの表記を対応とみなし、「非対応」「不可」や表記がない場合は false のままとします
+func extractShipsInternationallyField(in *ExtractInput, item *model.Item) bool {
+	if item.ShipsInternationally {
+		return false
+	}
+	item.ShipsInternationally = parseInternationalShipping(otherInfoValue(in.Doc, "海外発送"))
+	return item.ShipsInternationally
+}
+
+// parseInternationalShipping は海外発送の表記が対応を示しているかを返します
+func parseInternationalShipping(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "非対応") || strings.Contains(text, "不可") || strings.Contains(text, "なし") {
+		return false
+	}
+	return strings.Contains(text, "対応") || strings.Contains(text, "可") || strings.Contains(text, "あり")
+}
+
+// relistPattern は再出品されたオークションであることを示す本文の表記にマッチします
+// 「自動再出品」（出品時の設定）は再出品された事実を示さないため対象外です
+var relistPattern = regexp.MustCompile(`(?:^|[^自動])再出品(?:された|です|の商品)`)
+
+// extractRelistedField は再出品かどうかがJSONで示されていない場合に、ページの表示から判定します
+// 説明文などの自由記述（例: "再出品された場合は…"）は再出品の事実を示さないため、pageNoticeText の範囲のみを対象とします
+func extractRelistedField(in *ExtractInput, item *model.Item) bool {
+	if item.IsRelisted {
+		return false
+	}
+	if in.NextData != nil && in.NextData.DetailItem().IsRelisted != nil {
+		// JSONで再出品でないと示されている場合は、その値を優先します
+		return false
+	}
+	item.IsRelisted = relistPattern.MatchString(pageNoticeText(in.Doc))
+	return item.IsRelisted
+}
+
+// extractItemLocationField は出品地域がJSONに含まれない場合にHTMLの商品情報欄から取得します
+func extractItemLocationField(in *ExtractInput, item *model.Item) bool {
+	if item.ItemLocation != "" {
+		return false
+	}
+	item.ItemLocation = otherInfoValue(in.Doc, "出品地域")
+	return item.ItemLocation != ""
+}
+
+// watchCountPattern と viewingNowPattern はページ本文の人数の表記（例: ウォッチ 12、この商品を見ている人 3人）にマッチします
+// 両者は別の指標のため、それぞれの見出しに続く数値のみを取得します
+var (
+	watchCountPattern = regexp.MustCompile(`ウォッチ(?:数)?\s*[:：]?\s*([0-9,]+)`)
+	viewingNowPattern = regexp.MustCompile(`この商品を見ている人\s*[:：]?\s*([0-9,]+)`)
+)
+
+// extractWatchCountField はウォッチしている人数がJSONに含まれない場合に、ページ本文の表記から取得します
+func extractWatchCountField(in *ExtractInput, item *model.Item) bool {
+	if item.WatchCount > 0 {
+		return false
+	}
+	item.WatchCount = matchCount(watchCountPattern, pageNoticeText(in.Doc))
+	return item.WatchCount > 0
+}
+
+// extractViewingNowCountField はこの商品を見ている人数がJSONに含まれない場合に、ページ本文の表記から取得します
+func extractViewingNowCountField(in *ExtractInput, item *model.Item) bool {
+	if item.ViewingNowCount > 0 {
+		return false
+	}
+	item.ViewingNowCount = matchCount(viewingNowPattern, pageNoticeText(in.Doc))
+	return item.ViewingNowCount > 0
+}
+
+// freeTextSelectors は出品者や他の商品に由来する自由記述の領域です
+// タイトル（例: "スマートウォッチ 2台"）や説明文の語句を、ページの表示（ウォッチ数など）と取り違えないよう対象外とします
+const freeTextSelectors = "script, style, noscript, h1, div.ProductExplanation__commentBody, #description, section#recommend, section#qanda"
+
+// pageNoticeText はページ本文から自由記述の領域（freeTextSelectors）を除いたテキストを返します
+// ページが表示する件数や注意書きを、本文の表記から判定する場合に利用します
+func pageNoticeText(doc *goquery.Document) string {
+	body := doc.Find("body").Clone()
+	body.Find(freeTextSelectors).Remove()
+	return body.Text()
+}
+
+// matchCount は re の最初のキャプチャを件数として返します。マッチしない場合は0です
+func matchCount(re *regexp.Regexp, text string) int64 {
+	m := re.FindStringSubmatch(text)
+	if m == nil {
+		return 0
+	}
+	return parseCount(m[1])
+}
@@ -0,0 +1,419 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestBuildFieldExtractors(t *testing.T) {
+	t.Parallel()
+
+	noop := FieldExtractorFunc(func(in *ExtractInput, item *model.Item) bool { return false })
+	got := buildFieldExtractors([]namedExtractor{
+		{field: FieldCondition, extractor: noop},
+		{field: "title", extractor: noop},
+	})
+
+	if len(got) != len(defaultFieldExtractors)+1 {
+		t.Fatalf("pipeline length got %d, want %d", len(got), len(defaultFieldExtractors)+1)
+	}
+	for i, e := range defaultFieldExtractors {
+		if got[i].field != e.field {
+			t.Errorf("pipeline[%d] got %q, want %q", i, got[i].field, e.field)
+		}
+	}
+	if got[len(got)-1].field != "title" {
+		t.Errorf("last field got %q, want %q", got[len(got)-1].field, "title")
+	}
+	if len(defaultFieldExtractors) != 16 {
+		t.Errorf("default pipeline was modified: %d entries", len(defaultFieldExtractors))
+	}
+}
+
+func TestYahooScraper_extractItemInfo_overrideFieldExtractor(t *testing.T) {
+	t.Parallel()
+
+	html := `<html><head><script id="__NEXT_DATA__">` +
+		`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"json title"}}}}}}}` +
+		`</script></head><body><dl><dt>商品の状態</dt><dd>未使用</dd></dl><h1 class="NewTitle">html title</h1></body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	s := newYahooScraper(newOptions(defaultItemBaseURL, []Option{
+		// 既定の処理を差し替える
+		WithFieldExtractor(FieldCondition, FieldExtractorFunc(func(in *ExtractInput, item *model.Item) bool {
+			item.Condition = model.ConditionPoor
+			return true
+		})),
+		// 既定にないフィールドを追加する
+		WithFieldExtractor("title", FieldExtractorFunc(func(in *ExtractInput, item *model.Item) bool {
+			item.Title = strings.TrimSpace(in.Doc.Find("h1.NewTitle").Text())
+			return true
+		})),
+	})).(*yahooScraper)
+
+	got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Condition != model.ConditionPoor {
+		t.Errorf("Condition got %v, want %v", got.Condition, model.ConditionPoor)
+	}
+	if got.Title != "html title" {
+		t.Errorf("Title got %q, want %q", got.Title, "html title")
+	}
+}
+
+func TestParseInternationalShipping(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{in: "対応", want: true},
+		{in: "海外発送可", want: true},
+		{in: "あり", want: true},
+		{in: "非対応", want: false},
+		{in: "不可", want: false},
+		{in: "", want: false},
+	}
+
+	for _, tc := range cases {
+		if got := parseInternationalShipping(tc.in); got != tc.want {
+			t.Errorf("parseInternationalShipping(%q) got %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestYahooScraper_extractItemInfo_shipsInternationally(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want bool
+	}{
+		{name: "from json", json: `{"isInternationalShipping":true}`, want: true},
+		{name: "from html", json: `{}`, body: `<dl><dt>海外発送</dt><dd>対応</dd></dl>`, want: true},
+		{name: "not supported", json: `{}`, body: `<dl><dt>海外発送</dt><dd>非対応</dd></dl>`, want: false},
+		{name: "not indicated", json: `{}`, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` +
+				tc.json + `}}}}}}</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ShipsInternationally != tc.want {
+				t.Errorf("ShipsInternationally got %v, want %v", got.ShipsInternationally, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_relisted(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want bool
+	}{
+		{name: "from json", json: `{"isRelisted":true}`, want: true},
+		{name: "from text", json: `{}`, body: `<p>この商品は再出品された商品です</p>`, want: true},
+		{name: "json says not relisted", json: `{"isRelisted":false}`, body: `<p>この商品は再出品された商品です</p>`, want: false},
+		{
+			name: "mentioned in description",
+			json: `{}`,
+			body: `<div id="description">前回落札者都合のため再出品の商品です。再出品された場合もお値下げはしません</div>` +
+				`<div class="ProductExplanation__commentBody">再出品です</div>`,
+			want: false,
+		},
+		{name: "mentioned in title", json: `{}`, body: `<h1>【再出品です】カメラ</h1>`, want: false},
+		{name: "auto relist setting only", json: `{}`, body: `<dl><dt>自動再出品</dt><dd>あり（3回）</dd></dl><p>自動再出品されます</p>`, want: false},
+		{name: "not indicated", json: `{}`, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` +
+				tc.json + `}}}}}}</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.IsRelisted != tc.want {
+				t.Errorf("IsRelisted got %v, want %v", got.IsRelisted, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_itemLocation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want string
+	}{
+		{name: "from json", json: `{"itemLocation":" 北海道 "}`, body: `<dl><dt>出品地域</dt><dd>東京都</dd></dl>`, want: "北海道"},
+		{name: "from other info", json: `{}`, body: `<dl><dt>発送元の地域</dt><dd>大阪府</dd><dt>出品地域</dt><dd>東京都</dd></dl>`, want: "東京都"},
+		{name: "absent", json: `{}`, want: ""},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` +
+				tc.json + `}}}}}}</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ItemLocation != tc.want {
+				t.Errorf("ItemLocation got %q, want %q", got.ItemLocation, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_shippingSizeAndWeight(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		json       string
+		body       string
+		wantSize   string
+		wantWeight int64
+	}{
+		{
+			name:       "from json",
+			json:       `{"shippingSize":"80サイズ","shippingWeight":"2kg"}`,
+			body:       `<dl><dt>荷物のサイズ</dt><dd>100サイズ</dd></dl>`,
+			wantSize:   "80サイズ",
+			wantWeight: 2000,
+		},
+		{
+			name:       "from other info",
+			json:       `{}`,
+			body:       `<table><tr><th>荷物のサイズ</th><td>６０サイズ</td></tr><tr><th>荷物の重量</th><td>500g</td></tr></table>`,
+			wantSize:   "60サイズ",
+			wantWeight: 500,
+		},
+		{name: "absent", json: `{}`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` +
+				tc.json + `}}}}}}</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ShippingSize != tc.wantSize {
+				t.Errorf("ShippingSize got %q, want %q", got.ShippingSize, tc.wantSize)
+			}
+			if got.ShippingWeight != tc.wantWeight {
+				t.Errorf("ShippingWeight got %d, want %d", got.ShippingWeight, tc.wantWeight)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_watchCounts(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		json        string
+		body        string
+		wantWatch   int64
+		wantViewing int64
+	}{
+		{name: "from json", json: `{"watchListNum":12,"viewingNowNum":3}`, body: `<p>ウォッチ 99</p>`, wantWatch: 12, wantViewing: 3},
+		{name: "from text", json: `{}`, body: `<span>ウォッチ 1,024</span><span>この商品を見ている人 5人</span>`, wantWatch: 1024, wantViewing: 5},
+		{name: "watch only", json: `{}`, body: `<span>ウォッチ 7</span>`, wantWatch: 7},
+		{
+			name: "wristwatch in title and description",
+			json: `{}`,
+			body: `<h1>スマートウォッチ 2台セット</h1><div id="description">Apple ウォッチ 7 です。この商品を見ている人 3人</div>` +
+				`<section id="recommend"><li>ウォッチ 5本</li></section><script>var t = "ウォッチ 9";</script>`,
+		},
+		{name: "wristwatch title with count", json: `{}`, body: `<h1>スマートウォッチ 2台</h1><span>ウォッチ 4</span>`, wantWatch: 4},
+		{name: "absent", json: `{}`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` +
+				tc.json + `}}}}}}</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.WatchCount != tc.wantWatch {
+				t.Errorf("WatchCount got %d, want %d", got.WatchCount, tc.wantWatch)
+			}
+			if got.ViewingNowCount != tc.wantViewing {
+				t.Errorf("ViewingNowCount got %d, want %d", got.ViewingNowCount, tc.wantViewing)
+			}
+		})
+	}
+}
+
+// TestYahooScraper_FetchByID_withoutNextData は埋め込みJSONのないページでも、メタデータとHTMLから商品情報を抽出し、
+// 主経路での抽出の失敗として記録することを確認します
+func TestYahooScraper_FetchByID_withoutNextData(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head>` +
+			`<meta property="og:title" content="meta title">` +
+			`<meta property="og:price:amount" content="12,800">` +
+			`<meta property="og:image" content="https://example.com/main.jpg">` +
+			`</head><body><dl><dt>発送までの日数</dt><dd>1～2日で発送</dd></dl><span>ウォッチ 3</span></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithExtractionHealth(4, 0.5, 0)}))
+	got, err := repo.FetchByID(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "meta title" {
+		t.Errorf("Title got %q, want %q", got.Title, "meta title")
+	}
+	if got.CurrentPrice != 12800 {
+		t.Errorf("CurrentPrice got %d, want 12800", got.CurrentPrice)
+	}
+	if got.ShippingDays != "1～2日で発送" {
+		t.Errorf("ShippingDays got %q, want %q", got.ShippingDays, "1～2日で発送")
+	}
+	if got.WatchCount != 3 {
+		t.Errorf("WatchCount got %d, want 3", got.WatchCount)
+	}
+	if h := repo.(HealthProvider).Health(); h.Samples != 1 || h.SuccessRate != 0 {
+		t.Errorf("health got %+v, want 1 sample recorded as a failure", h)
+	}
+}
+
+func TestYahooScraper_extractItemInfo_priceFromStructuredData(t *testing.T) {
+	t.Parallel()
+
+	// 埋め込みJSONに価格がなく、価格のCSSクラスもないページ
+	nextData := `<script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title"}}}}}}}</script>`
+	cases := []struct {
+		name string
+		head string
+		want int64
+	}{
+		{
+			name: "og meta",
+			head: `<meta property="og:title" content="title"><meta property="og:price:amount" content="12,800"><meta property="og:price:currency" content="JPY">`,
+			want: 12800,
+		},
+		{
+			name: "product meta preferred",
+			head: `<meta property="og:price:amount" content="100"><meta property="product:price:amount" content="200">`,
+			want: 200,
+		},
+		{
+			name: "json-ld offers object",
+			head: `<script type="application/ld+json">{"@type":"Product","name":"title","offers":{"@type":"Offer","price":"5000","priceCurrency":"JPY"}}</script>`,
+			want: 5000,
+		},
+		{
+			name: "json-ld offers array with numeric price",
+			head: `<script type="application/ld+json">[{"@type":"Product","offers":[{"@type":"Offer","price":7500}]}]</script>`,
+			want: 7500,
+		},
+		{
+			name: "none",
+			head: `<meta property="og:title" content="title">`,
+			want: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><head>` + nextData + tc.head + `</head><body></body></html>`))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := newYahooScraper(newOptions(defaultItemBaseURL, nil)).(*yahooScraper)
+			item, err := s.extractItemInfo(context.Background(), doc, "x1", model.ItemFieldsAll)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if item.CurrentPrice != tc.want {
+				t.Errorf("CurrentPrice got %d, want %d", item.CurrentPrice, tc.want)
+			}
+		})
+	}
+}
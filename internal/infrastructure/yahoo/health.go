@@ -0,0 +1,110 @@
+package yahoo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// minHealthSamples は Health で不健全と判定するために必要な最小の記録数です
+// 起動直後の数件の失敗だけで不健全と判定しないために利用します（ウィンドウの大きさがこれより小さい場合はウィンドウの大きさ）
+const minHealthSamples = 10
+
+// Health は直近の商品詳細の抽出の成功率から求めたスクレイパーの健全性です
+type Health struct {
+	Samples     int     // ウィンドウ内の（期限切れでない）記録数
+	SuccessRate float64 // ウィンドウ内の抽出の成功率（0〜1）。記録がない場合は 1
+	MinRate     float64 // 健全と判定する成功率の下限
+	Healthy     bool    // 成功率が下限以上か、記録数が判定に必要な数に満たない場合に true
+}
+
+// Err は不健全な場合に理由を表すエラーを返します。健全な場合は nil を返します
+func (h Health) Err() error {
+	if h.Healthy {
+		return nil
+	}
+	return fmt.Errorf("extraction success rate %.2f is below %.2f over the last %d requests", h.SuccessRate, h.MinRate, h.Samples)
+}
+
+// HealthProvider は Health を提供するスクレイパーが実装するインターフェースです
+// Stats と同様に、型アサーションで取得します
+type HealthProvider interface {
+	Health() Health
+}
+
+// healthSample は抽出1回分の結果です
+type healthSample struct {
+	ok bool      // 主経路での抽出に成功したか
+	at time.Time // 記録した時刻
+}
+
+// extractionHealth は直近 window 件の抽出結果を保持するスライディングウィンドウです
+// maxAge より古い結果は判定に使いません。不健全と判定されてトラフィックが止まり、新しい結果が記録されなくなっても、
+// 古い失敗が期限切れになれば記録数が判定に必要な数を下回り、健全な状態に戻ります
+// 並行に呼び出されても安全です。nil の場合は何も記録せず、常に健全と判定します
+type extractionHealth struct {
+	mu      sync.Mutex
+	results []healthSample // リングバッファ
+	next    int            // 次に書き込む位置
+	count   int            // 記録済みの件数（最大 len(results)）
+	minRate float64
+	maxAge  time.Duration // 0 の場合は期限切れにしない
+	now     func() time.Time
+}
+
+// newExtractionHealth は直近 maxAge 以内の最大 window 件の抽出結果から健全性を判定する extractionHealth を作成します
+// window が 0 以下の場合は nil を返します（判定しない）
+func newExtractionHealth(window int, minRate float64, maxAge time.Duration) *extractionHealth {
+	if window <= 0 {
+		return nil
+	}
+	return &extractionHealth{results: make([]healthSample, window), minRate: minRate, maxAge: maxAge, now: time.Now}
+}
+
+// record は抽出1回分の結果を記録します。ウィンドウが一杯の場合は最も古い結果を上書きします
+func (h *extractionHealth) record(ok bool) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[h.next] = healthSample{ok: ok, at: h.now()}
+	h.next = (h.next + 1) % len(h.results)
+	if h.count < len(h.results) {
+		h.count++
+	}
+}
+
+// snapshot は現在のウィンドウから求めた健全性を返します
+func (h *extractionHealth) snapshot() Health {
+	if h == nil {
+		return Health{SuccessRate: 1, Healthy: true}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var cutoff time.Time
+	if h.maxAge > 0 {
+		cutoff = h.now().Add(-h.maxAge)
+	}
+	samples, successes := 0, 0
+	for _, r := range h.results[:h.count] {
+		if r.at.Before(cutoff) {
+			continue
+		}
+		samples++
+		if r.ok {
+			successes++
+		}
+	}
+
+	health := Health{Samples: samples, SuccessRate: 1, MinRate: h.minRate, Healthy: true}
+	if samples == 0 {
+		return health
+	}
+	health.SuccessRate = float64(successes) / float64(samples)
+	if samples >= min(minHealthSamples, len(h.results)) {
+		health.Healthy = health.SuccessRate >= h.minRate
+	}
+	return health
+}
@@ -0,0 +1,132 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExtractionHealth_slidingWindow(t *testing.T) {
+	t.Parallel()
+
+	h := newExtractionHealth(10, 0.5, 0)
+	if got := h.snapshot(); !got.Healthy || got.Samples != 0 || got.SuccessRate != 1 {
+		t.Fatalf("initial health got %+v, want healthy with no samples", got)
+	}
+
+	for i := 0; i < 9; i++ {
+		h.record(false)
+	}
+	if got := h.snapshot(); !got.Healthy {
+		t.Errorf("health with %d samples got %+v, want healthy until the window has enough samples", got.Samples, got)
+	}
+
+	h.record(false)
+	got := h.snapshot()
+	if got.Healthy || got.Samples != 10 || got.SuccessRate != 0 {
+		t.Errorf("got %+v, want unhealthy with 10 samples and rate 0", got)
+	}
+	if got.Err() == nil {
+		t.Error("Err() should be non-nil when unhealthy")
+	}
+
+	// 古い失敗はウィンドウから押し出される
+	for i := 0; i < 5; i++ {
+		h.record(true)
+	}
+	if got := h.snapshot(); !got.Healthy || got.SuccessRate != 0.5 {
+		t.Errorf("got %+v, want healthy with rate 0.5", got)
+	}
+	for i := 0; i < 6; i++ {
+		h.record(false)
+	}
+	if got := h.snapshot(); got.Healthy || got.Samples != 10 || got.SuccessRate != 0.4 {
+		t.Errorf("got %+v, want unhealthy with rate 0.4", got)
+	}
+}
+
+// TestExtractionHealth_recoversAfterMaxAge は不健全と判定された後に記録が止まっても、
+// 古い失敗が期限切れになれば健全な状態に戻ることを確認します
+func TestExtractionHealth_recoversAfterMaxAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newExtractionHealth(10, 0.5, 10*time.Minute)
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		h.record(false)
+	}
+	if got := h.snapshot(); got.Healthy {
+		t.Fatalf("got %+v, want unhealthy", got)
+	}
+
+	// /readyz の失敗でトラフィックが止まり、新しい結果は記録されない
+	now = now.Add(9 * time.Minute)
+	if got := h.snapshot(); got.Healthy || got.Samples != 10 {
+		t.Errorf("got %+v, want still unhealthy within max age", got)
+	}
+	now = now.Add(2 * time.Minute)
+	if got := h.snapshot(); !got.Healthy || got.Samples != 0 {
+		t.Errorf("got %+v, want healthy with no samples after max age", got)
+	}
+
+	// 期限内の結果のみで判定する
+	for i := 0; i < 10; i++ {
+		h.record(true)
+	}
+	if got := h.snapshot(); !got.Healthy || got.SuccessRate != 1 {
+		t.Errorf("got %+v, want healthy with rate 1", got)
+	}
+}
+
+func TestExtractionHealth_nil(t *testing.T) {
+	t.Parallel()
+
+	h := newExtractionHealth(0, 0.5, 0)
+	h.record(false)
+	if got := h.snapshot(); !got.Healthy || got.Err() != nil {
+		t.Errorf("got %+v, want always healthy when disabled", got)
+	}
+}
+
+func TestYahooScraper_Health(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jp/auction/ok":
+			_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"auctionId":"ok","title":"t"}}}}}}}</script></head></html>`))
+		case "/jp/auction/untitled":
+			_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"auctionId":"untitled"}}}}}}}</script></head></html>`))
+		case "/jp/auction/broken":
+			_, _ = w.Write([]byte(`<html><body>no data</body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithExtractionHealth(4, 0.5, 0)}))
+	provider, ok := repo.(HealthProvider)
+	if !ok {
+		t.Fatal("scraper does not implement HealthProvider")
+	}
+
+	for _, id := range []string{"ok", "untitled", "broken", "missing"} {
+		_, _ = repo.FetchByID(context.Background(), id)
+	}
+	// 404 はページの取得の失敗のため記録されない
+	got := provider.Health()
+	if got.Samples != 3 || !got.Healthy {
+		t.Fatalf("got %+v, want 3 samples and healthy (window not yet filled)", got)
+	}
+
+	_, _ = repo.FetchByID(context.Background(), "broken")
+	got = provider.Health()
+	if got.Samples != 4 || got.SuccessRate != 0.25 || got.Healthy {
+		t.Errorf("got %+v, want 4 samples, rate 0.25 and unhealthy", got)
+	}
+}
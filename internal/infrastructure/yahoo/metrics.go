@@ -0,0 +1,48 @@
+package yahoo
+
+import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+)
+
+// fallbackCounterName はHTMLへのフォールバック回数を表すメトリクス名です
+// 値が増加している場合、ヤフオク側のJSONスキーマが変更された可能性があります
+const fallbackCounterName = "yahoo.extraction.fallbacks"
+
+// attrFallbackField はフォールバックしたフィールド名の属性キーです
+const attrFallbackField = attribute.Key("yahoo.fallback.field")
+
+// defaultMeter はメーターが設定されていない場合に利用する no-op のメーターです
+func defaultMeter() metric.Meter {
+	return metricnoop.NewMeterProvider().Meter(tracerName)
+}
+
+// newFallbackCounter はHTMLへのフォールバック回数のカウンターを作成します
+// 作成に失敗した場合は no-op のカウンターを返します
+func newFallbackCounter(meter metric.Meter) metric.Int64Counter {
+	if meter == nil {
+		meter = defaultMeter()
+	}
+	counter, err := meter.Int64Counter(
+		fallbackCounterName,
+		metric.WithDescription("Number of fields extracted from HTML because they were missing from the embedded JSON"),
+	)
+	if err != nil {
+		log.Printf("warning: failed to create %s counter: %v", fallbackCounterName, err)
+		return metricnoop.Int64Counter{}
+	}
+	return counter
+}
+
+// recordFallback はHTMLへのフォールバックをログに残し、カウンターを加算します
+// counter が nil の場合はログのみ残します
+func recordFallback(ctx context.Context, counter metric.Int64Counter, auctionID, field string) {
+	log.Printf("warning: %s for %s was extracted from html instead of next data", field, auctionID)
+	if counter != nil {
+		counter.Add(ctx, 1, metric.WithAttributes(attrFallbackField.String(field)))
+	}
+}
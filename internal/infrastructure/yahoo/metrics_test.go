@@ -0,0 +1,85 @@
+package yahoo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// recordingCounter は加算されたフィールド名を記録するテスト用カウンターです
+type recordingCounter struct {
+	metricnoop.Int64Counter
+	mu     sync.Mutex
+	fields []string
+}
+
+func (c *recordingCounter) Add(_ context.Context, incr int64, opts ...metric.AddOption) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cfg := metric.NewAddConfig(opts)
+	attrs := cfg.Attributes()
+	v, _ := attrs.Value(attrFallbackField)
+	for i := int64(0); i < incr; i++ {
+		c.fields = append(c.fields, v.AsString())
+	}
+}
+
+func TestYahooScraper_extractItemInfo_recordsFallback(t *testing.T) {
+	t.Parallel()
+
+	const body = `<table><tr><th>発送元の地域</th><td>大阪府</td></tr><tr><th>発送までの日数</th><td>1～2日で発送</td></tr></table>`
+
+	cases := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{
+			name: "values only in html",
+			json: `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t"}}}}}}}`,
+			want: []string{"seller_location", "shipping_days"},
+		},
+		{
+			name: "values in json",
+			json: `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"shipSchedule":"3日","seller":{"prefecture":"東京都"}}}}}}}}`,
+			want: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head><body>` + body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			counter := &recordingCounter{}
+			s := &yahooScraper{fallbacks: counter}
+			if _, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldSeller); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.Join(counter.fields, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("fallbacks got %v, want %v", counter.fields, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewFallbackCounter_nilMeter(t *testing.T) {
+	t.Parallel()
+
+	if newFallbackCounter(nil) == nil {
+		t.Fatalf("newFallbackCounter(nil) returned nil")
+	}
+}
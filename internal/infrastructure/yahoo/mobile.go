@@ -0,0 +1,96 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// defaultMobileItemBaseURL はモバイル版の商品詳細ページのデフォルトのベースURLです
+const defaultMobileItemBaseURL = "https://m.page.auctions.yahoo.co.jp"
+
+// mobileUserAgent はモバイル版ページの取得に利用するUser-Agentです
+const mobileUserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+
+// errMobileItemNotFound はモバイル版ページから商品情報を抽出できなかった場合のエラーです
+var errMobileItemNotFound = errors.New("item not found in mobile page")
+
+// userAgentTransport はリクエストの User-Agent を差し替える http.RoundTripper です
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip は User-Agent を差し替えたリクエストを base に渡します
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return base.RoundTrip(req)
+}
+
+// mobileClient は client の設定を引き継ぎ、モバイル版のUser-Agentでリクエストする http.Client を返します
+func mobileClient(client *http.Client) *http.Client {
+	c := *client
+	c.Transport = &userAgentTransport{base: client.Transport, userAgent: mobileUserAgent}
+	return &c
+}
+
+// fetchMobileItem はモバイル版の商品詳細ページから商品情報を取得します
+// デスクトップ版ページの構造変更で抽出に失敗した場合のフォールバックとして利用します
+func (s *yahooScraper) fetchMobileItem(ctx context.Context, auctionID string, fields model.ItemFields) (item *model.Item, err error) {
+	url := fmt.Sprintf("%s/jp/auction/%s", s.mobileBaseURL, auctionID)
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchMobileItem", attrAuctionID.String(auctionID), attrURL.String(url))
+	defer func() { endSpan(span, err) }()
+
+	doc, err := fetchHTML(ctx, mobileClient(s.client), url, s.retry, s.validators, s.solver)
+	if err != nil {
+		return nil, err
+	}
+	dumpDocument(s.debugDumpDir, "auction_mobile", auctionID, doc, time.Now())
+
+	return s.extractMobileItemInfo(doc, auctionID, fields)
+}
+
+// extractMobileItemInfo はモバイル版ページから商品情報を抽出します
+// 埋め込みJSONがあればデスクトップ版と同じマッピングを使い、なければ meta タグから最低限の情報を取得します
+func (s *yahooScraper) extractMobileItemInfo(doc *goquery.Document, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	if nextData, err := ParseNextData(doc); err == nil && nextData.HasDetailItem() {
+		item := s.extractItemFromJSONWithFields(nextData, auctionID, fields)
+		if s.normalizeText {
+			applyTextNormalization(item)
+		}
+		return item, nil
+	}
+
+	title := ogTitle(doc)
+	if title == "" {
+		return nil, errMobileItemNotFound
+	}
+
+	item := &model.Item{
+		AuctionID:   auctionID,
+		Title:       title,
+		AuctionInfo: &model.AuctionInformation{AuctionID: auctionID},
+	}
+	item.Thumbnail = ogImage(doc)
+	if fields.Has(model.ItemFieldImages) {
+		item.Images = []string{}
+		if item.Thumbnail != "" {
+			item.Images = append(item.Images, item.Thumbnail)
+		}
+	}
+	if s.normalizeText {
+		applyTextNormalization(item)
+	}
+	return item, nil
+}
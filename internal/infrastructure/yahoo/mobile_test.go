@@ -0,0 +1,108 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// newMobileTestServer はデスクトップ版では抽出に失敗し、モバイル版（/m 配下）では mobileBody を返すサーバーです
+func newMobileTestServer(t *testing.T, mobileBody string, mobileUA *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/m/") {
+			mobileUA.Store(strings.Contains(r.Header.Get("User-Agent"), "iPhone"))
+			_, _ = w.Write([]byte(mobileBody))
+			return
+		}
+		_, _ = w.Write([]byte(`<html><body>desktop markup changed</body></html>`))
+	}))
+}
+
+func TestYahooScraper_FetchByID_mobileFallback(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		body      string
+		wantTitle string
+		wantErr   bool
+	}{
+		{
+			name:      "mobile next data",
+			body:      `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"mobile title","price":1000,"status":"open"}}}}}}}</script></head></html>`,
+			wantTitle: "mobile title",
+		},
+		{
+			name:      "mobile meta only",
+			body:      `<html><head><meta property="og:title" content="meta title"></head></html>`,
+			wantTitle: "meta title",
+		},
+		{
+			name:    "mobile also fails",
+			body:    `<html><body></body></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mobileUA atomic.Bool
+			srv := newMobileTestServer(t, tc.body, &mobileUA)
+			defer srv.Close()
+
+			o := newOptions(srv.URL, []Option{WithHTTPClient(srv.Client()), WithMobileFallback()})
+			o.mobileBaseURL = srv.URL + "/m"
+			repo := newYahooScraper(o)
+
+			got, err := repo.FetchByID(context.Background(), "x1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Title != tc.wantTitle {
+				t.Errorf("Title got %q, want %q", got.Title, tc.wantTitle)
+			}
+			if !mobileUA.Load() {
+				t.Errorf("mobile page was not requested with a mobile User-Agent")
+			}
+		})
+	}
+}
+
+func TestYahooScraper_FetchByID_mobileFallbackDisabled(t *testing.T) {
+	t.Parallel()
+
+	var mobileCalled atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/m/") {
+			mobileCalled.Store(true)
+		}
+		_, _ = w.Write([]byte(`<html><body></body></html>`))
+	}))
+	defer srv.Close()
+
+	o := newOptions(srv.URL, []Option{WithHTTPClient(srv.Client())})
+	o.mobileBaseURL = srv.URL + "/m"
+
+	if _, err := newYahooScraper(o).FetchByIDWithFields(context.Background(), "x1", model.ItemFieldsNone); err == nil {
+		t.Fatalf("expected error")
+	}
+	if mobileCalled.Load() {
+		t.Fatalf("mobile page should not be requested when fallback is disabled")
+	}
+}
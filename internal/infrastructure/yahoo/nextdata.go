@@ -0,0 +1,321 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// NextData はNext.jsのJSON構造体です
+// 商品詳細ページの script#__NEXT_DATA__ に埋め込まれたJSONのうち、利用するフィールドのみを定義します
+// ヤフオク側のスキーマ変更時はこの型だけを修正すれば済むよう、JSONの構造はここに集約します
+type NextData struct {
+	// missingFields はJSON内に見つからなかった想定フィールドのパスです（ParseNextData が記録します）
+	missingFields []string
+	// schema は検出したスキーマの形式です（ParseNextData が記録します）
+	schema nextDataSchema
+	// raw はパース元のJSONです（ParseNextData が記録します）
+	raw json.RawMessage
+
+	BuildID string `json:"buildId"` // Next.js のビルドID。形式の切り替わりを調査する際の手がかりです
+
+	Props struct {
+		PageProps struct {
+			InitialState struct {
+				Item struct {
+					Detail struct {
+						Item NextDataItem `json:"item"`
+					} `json:"detail"`
+				} `json:"item"`
+				Recommend struct {
+					Items []NextDataRecommendItem `json:"items"`
+				} `json:"recommend"`
+			} `json:"initialState"`
+		} `json:"pageProps"`
+	} `json:"props"`
+}
+
+// NextDataItem は商品詳細のJSON構造体です
+type NextDataItem struct {
+	Title                   string                 `json:"title"`
+	Price                   int64                  `json:"price"`
+	TaxinPrice              int64                  `json:"taxinPrice"`
+	Status                  string                 `json:"status"`
+	Bids                    int64                  `json:"bids"`
+	DescriptionHtml         string                 `json:"descriptionHtml"`
+	InitPrice               int64                  `json:"initPrice"`
+	Bidorbuy                int64                  `json:"bidorbuy"`            // 即決価格。即決価格がない場合は0
+	TaxinBidorbuy           int64                  `json:"taxinBidorbuy"`       // 税込の即決価格
+	IsBidorbuyAvailable     *bool                  `json:"isBidorbuyAvailable"` // 現在即決で落札できるか。ページに含まれない場合は nil
+	TaxinStartPrice         int64                  `json:"taxinStartPrice"`
+	StartTime               string                 `json:"startTime"` // ISO 8601
+	EndTime                 string                 `json:"endTime"`   // ISO 8601
+	IsEarlyClosing          bool                   `json:"isEarlyClosing"`
+	IsAutomaticExtension    bool                   `json:"isAutomaticExtension"`
+	TotalAccessCount        int64                  `json:"totalAccessCount"`
+	ItemReturnable          NextDataItemReturnable `json:"itemReturnable"`
+	ShipSchedule            string                 `json:"shipSchedule"`   // 発送までの日数
+	ShippingSize            string                 `json:"shippingSize"`   // 荷物のサイズ（例: "80サイズ"）
+	ShippingWeight          string                 `json:"shippingWeight"` // 荷物の重量（例: "2kg"）
+	ConditionName           string                 `json:"conditionName"`  // 商品の状態（例: "未使用に近い"）
+	IsInternationalShipping bool                   `json:"isInternationalShipping"`
+	IsRelisted              *bool                  `json:"isRelisted"`   // 再出品されたか。ページに含まれない場合は nil
+	ItemLocation            string                 `json:"itemLocation"` // 出品地域（都道府県など）
+	ProductCode             string                 `json:"productCode"`  // ストアが管理する商品コード
+	BidRestriction          NextDataBidRestriction `json:"bidderRestriction"`
+	Tags                    []string               `json:"tags"` // 検索用のタグ
+	Variations              []NextDataVariation    `json:"variations"`
+	WatchListNum            int64                  `json:"watchListNum"`  // ウォッチしている人数
+	ViewingNowNum           int64                  `json:"viewingNowNum"` // この商品を見ている人数
+	Seller                  NextDataSeller         `json:"seller"`
+	Promotion               NextDataPromotion      `json:"promotion"`
+	Questions               []NextDataQuestion     `json:"questions"`
+	Img                     []NextDataImage        `json:"img"`
+}
+
+// NextDataItemReturnable は返品可否のJSON構造体です
+type NextDataItemReturnable struct {
+	Allowed bool   `json:"allowed"`
+	Comment string `json:"comment"`
+}
+
+// NextDataSeller は出品者のJSON構造体です
+type NextDataSeller struct {
+	AucUserID   string `json:"aucUserId"`
+	DisplayName string `json:"displayName"`
+	Prefecture  string `json:"prefecture"`
+	Rating      struct {
+		GoodRating int64 `json:"goodRating"`
+		BadRating  int64 `json:"badRating"`
+	} `json:"rating"`
+}
+
+// NextDataPromotion はストアのクーポンや注目のオークションなど販促情報のJSON構造体です
+type NextDataPromotion struct {
+	Coupons []struct {
+		Title string `json:"title"`
+	} `json:"coupons"`
+	IsFeatured bool `json:"isFeatured"` // 出品者が注目のオークション（有料オプション）を設定しているか
+}
+
+// NextDataQuestion は質問と回答のJSON構造体です
+type NextDataQuestion struct {
+	Question     string `json:"question"`
+	QuestionTime string `json:"questionTime"` // ISO 8601
+	Answer       string `json:"answer"`
+	AnswerTime   string `json:"answerTime"` // ISO 8601。未回答の場合は空
+}
+
+// NextDataVariation はストア出品の選択肢（サイズ・色など）のJSON構造体です
+type NextDataVariation struct {
+	Name       string `json:"name"`
+	Price      int64  `json:"price"`
+	TaxinPrice int64  `json:"taxinPrice"`
+	Stock      int64  `json:"stock"`
+}
+
+// NextDataBidRestriction は入札者の制限のJSON構造体です
+type NextDataBidRestriction struct {
+	IsPremiumOnly  bool `json:"isPremiumOnly"`  // Yahoo!プレミアム会員のみ入札できる
+	IsVerifiedOnly bool `json:"isVerifiedOnly"` // 本人確認済みの利用者のみ入札できる
+}
+
+// NextDataImage は商品画像のJSON構造体です
+type NextDataImage struct {
+	Image  string `json:"image"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	IsMain bool   `json:"isMain"` // メイン画像として指定されているか
+}
+
+// NextDataRecommendItem は関連商品（おすすめ）のJSON構造体です
+type NextDataRecommendItem struct {
+	AuctionID   string `json:"auctionId"`
+	Title       string `json:"title"`
+	Price       int64  `json:"price"`
+	BuyNowPrice int64  `json:"buyNowPrice"`
+	Bids        int64  `json:"bids"`
+	ImageURL    string `json:"imageUrl"`
+}
+
+// detailItemPath は商品詳細オブジェクトへのJSONパスです
+var detailItemPath = []string{"props", "pageProps", "initialState", "item", "detail", "item"}
+
+// expectedItemFields は商品詳細オブジェクトに存在することを想定しているキーです
+// 欠けている場合はスキーマ変更の兆候として警告の対象になります
+var expectedItemFields = []string{"title", "price", "status", "startTime", "endTime"}
+
+// DetailItem は商品詳細のJSONを返します
+func (d *NextData) DetailItem() *NextDataItem {
+	return &d.Props.PageProps.InitialState.Item.Detail.Item
+}
+
+// RecommendItems は関連商品（おすすめ）のJSONを返します
+func (d *NextData) RecommendItems() []NextDataRecommendItem {
+	return d.Props.PageProps.InitialState.Recommend.Items
+}
+
+// stateParser はHTMLに埋め込まれた状態（JSON）を NextData として取り出す関数です
+type stateParser func(doc *goquery.Document) (*NextData, error)
+
+// stateParsers は ParseNextData が順に試すパーサーのチェーンです
+// ページによっては #__NEXT_DATA__ 以外のスクリプトに状態が埋め込まれているため、代替の形式も確認します
+var stateParsers = []stateParser{
+	parseNextDataScript,
+	parseWindowStateScript,
+}
+
+// ParseNextData はHTMLからNext.jsのJSONデータを抽出・パースします
+// stateParsers を順に試し、商品詳細を含む最初の結果を返します
+// 商品詳細を含む結果がない場合は、パースに成功した最初の結果を返します
+func ParseNextData(doc *goquery.Document) (*NextData, error) {
+	var (
+		fallback *NextData
+		errs     []error
+	)
+	for _, parse := range stateParsers {
+		data, err := parse(doc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if data.HasDetailItem() {
+			return data, nil
+		}
+		if fallback == nil {
+			fallback = data
+		}
+	}
+
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ErrNextDataNotFound はページに状態を埋め込んだスクリプトが見つからない場合のエラーです
+var ErrNextDataNotFound = errors.New("next data not found")
+
+// parseNextDataScript は script#__NEXT_DATA__ のJSONをパースします
+func parseNextDataScript(doc *goquery.Document) (*NextData, error) {
+	scriptContent := doc.Find("script#__NEXT_DATA__").Text()
+	if scriptContent == "" {
+		return nil, fmt.Errorf("next data script: %w", ErrNextDataNotFound)
+	}
+
+	return unmarshalNextData([]byte(scriptContent))
+}
+
+// windowStatePattern は window.__INITIAL_STATE__ = {...}; 形式の代入文にマッチします
+var windowStatePattern = regexp.MustCompile(`(?s)window\.(?:__INITIAL_STATE__|__PRELOADED_STATE__)\s*=\s*(\{.*\})\s*;?\s*$`)
+
+// parseWindowStateScript は window.__INITIAL_STATE__ などに代入された状態をパースします
+// 代入される値は __NEXT_DATA__ の props.pageProps.initialState と同じ形であることを想定しています
+func parseWindowStateScript(doc *goquery.Document) (*NextData, error) {
+	var state string
+	doc.Find("script").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if m := windowStatePattern.FindStringSubmatch(strings.TrimSpace(s.Text())); m != nil {
+			state = m[1]
+			return false
+		}
+		return true
+	})
+	if state == "" {
+		return nil, fmt.Errorf("window state script: %w", ErrNextDataNotFound)
+	}
+
+	// NextData と同じ構造で扱えるよう props.pageProps.initialState の下に配置する
+	wrapped := `{"props":{"pageProps":{"initialState":` + state + `}}}`
+	return unmarshalNextData([]byte(wrapped))
+}
+
+// unmarshalNextData はJSONを NextData にパースし、スキーマの形式と想定フィールドの有無を記録します
+func unmarshalNextData(raw []byte) (*NextData, error) {
+	var data NextData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal next data: %w", err)
+	}
+
+	data.schema = detectNextDataSchema(raw)
+	if err := decodeSchemaFields(raw, data.schema, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal next data (%s schema): %w", data.schema.name, err)
+	}
+	data.missingFields = probeMissingFields(raw, data.schema.itemPath)
+	data.raw = raw
+
+	return &data, nil
+}
+
+// Raw はパース元のJSONを返します
+// window.__INITIAL_STATE__ から取得した場合は、__NEXT_DATA__ と同じ props.pageProps.initialState の下に配置した形になります
+func (d *NextData) Raw() json.RawMessage {
+	return d.raw
+}
+
+// Schema は検出したスキーマの形式の名前を返します
+func (d *NextData) Schema() string {
+	return d.schema.name
+}
+
+// HasDetailItem は商品詳細オブジェクトがJSON内に存在したかどうかを返します
+// false の場合、JSONとしては正しいもののスキーマが想定と異なる可能性があります
+func (d *NextData) HasDetailItem() bool {
+	itemPath := strings.Join(d.schema.itemPath, ".")
+	for _, f := range d.missingFields {
+		if f == itemPath {
+			return false
+		}
+	}
+	return true
+}
+
+// MissingFields はJSON内に見つからなかった想定フィールドのパスを返します
+func (d *NextData) MissingFields() []string {
+	return d.missingFields
+}
+
+// probeMissingFields はJSONを走査し、itemPath の商品詳細オブジェクトについて想定しているキーのうち存在しないものを返します
+// 商品詳細オブジェクト自体がない場合は、そのパスのみを返します
+func probeMissingFields(raw []byte, path []string) []string {
+	itemPath := strings.Join(path, ".")
+
+	item, ok := probePath(raw, path...)
+	if !ok {
+		return []string{itemPath}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(item, &fields); err != nil {
+		return []string{itemPath}
+	}
+
+	var missing []string
+	for _, key := range expectedItemFields {
+		if _, ok := fields[key]; !ok {
+			missing = append(missing, itemPath+"."+key)
+		}
+	}
+	return missing
+}
+
+// probePath はJSONオブジェクトを path の順にたどり、末端の値を返します
+// 途中のキーが存在しない、またはオブジェクトでない場合は false を返します
+func probePath(raw []byte, path ...string) (json.RawMessage, bool) {
+	current := json.RawMessage(raw)
+	for _, key := range path {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(current, &obj); err != nil {
+			return nil, false
+		}
+		next, ok := obj[key]
+		if !ok || string(next) == "null" {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
@@ -0,0 +1,73 @@
+package yahoo
+
+import "encoding/json"
+
+// nextDataSchema は埋め込まれた状態（JSON）のスキーマの種類です
+// ヤフオクはページの形式をA/Bテストで切り替えることがあるため、商品詳細の位置が異なる形式を区別します
+// 新しい形式に対応する場合は nextDataSchemas に1件追加します
+type nextDataSchema struct {
+	// name はログやデバッグで利用する形式の名前です
+	name string
+	// itemPath は商品詳細オブジェクトへのJSONパスです
+	itemPath []string
+	// recommendPath は関連商品（おすすめ）の配列へのJSONパスです。ない形式の場合は nil
+	recommendPath []string
+}
+
+// detect は raw がこの形式かどうかを商品詳細オブジェクトの有無で判定します
+func (s nextDataSchema) detect(raw []byte) bool {
+	_, ok := probePath(raw, s.itemPath...)
+	return ok
+}
+
+// defaultNextDataSchema は現在の形式（Redux の initialState に商品詳細を持つ形式）です
+// NextData の構造体はこの形式に合わせて定義しています
+var defaultNextDataSchema = nextDataSchema{
+	name:          "initial_state",
+	itemPath:      detailItemPath,
+	recommendPath: []string{"props", "pageProps", "initialState", "recommend", "items"},
+}
+
+// nextDataSchemas は detectNextDataSchema が順に判定する形式の一覧です
+var nextDataSchemas = []nextDataSchema{
+	defaultNextDataSchema,
+	// initialState を経由せず、pageProps 直下に商品詳細を持つ形式
+	{
+		name:          "page_props",
+		itemPath:      []string{"props", "pageProps", "item"},
+		recommendPath: []string{"props", "pageProps", "recommend", "items"},
+	},
+}
+
+// detectNextDataSchema は raw に一致する最初の形式を返します
+// いずれにも一致しない場合は defaultNextDataSchema を返し、欠けているフィールドとして警告の対象になります
+func detectNextDataSchema(raw []byte) nextDataSchema {
+	for _, s := range nextDataSchemas {
+		if s.detect(raw) {
+			return s
+		}
+	}
+	return defaultNextDataSchema
+}
+
+// decodeSchemaFields は defaultNextDataSchema 以外の形式の場合に、商品詳細と関連商品を
+// 形式ごとのパスから取り出して NextData の対応する位置に設定します
+func decodeSchemaFields(raw []byte, schema nextDataSchema, data *NextData) error {
+	if schema.name == defaultNextDataSchema.name {
+		return nil
+	}
+
+	if item, ok := probePath(raw, schema.itemPath...); ok {
+		if err := json.Unmarshal(item, data.DetailItem()); err != nil {
+			return err
+		}
+	}
+	if schema.recommendPath != nil {
+		if items, ok := probePath(raw, schema.recommendPath...); ok {
+			if err := json.Unmarshal(items, &data.Props.PageProps.InitialState.Recommend.Items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package yahoo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestParseNextData_tracksMissingFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		json           string
+		wantHasDetail  bool
+		wantMissingLen int
+	}{
+		{
+			name:           "all expected fields present",
+			json:           `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t","price":1,"status":"open","startTime":"","endTime":""}}}}}}}`,
+			wantHasDetail:  true,
+			wantMissingLen: 0,
+		},
+		{
+			name:           "some fields missing",
+			json:           `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t"}}}}}}}`,
+			wantHasDetail:  true,
+			wantMissingLen: 4,
+		},
+		{
+			name:           "valid json with unexpected shape",
+			json:           `{"props":{"pageProps":{"state":{"auction":{"title":"t"}}}}}`,
+			wantHasDetail:  false,
+			wantMissingLen: 1,
+		},
+		{
+			name:           "item is null",
+			json:           `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":null}}}}}}`,
+			wantHasDetail:  false,
+			wantMissingLen: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			data, err := ParseNextData(doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := data.HasDetailItem(); got != tc.wantHasDetail {
+				t.Errorf("HasDetailItem got %v, want %v", got, tc.wantHasDetail)
+			}
+			if got := data.MissingFields(); len(got) != tc.wantMissingLen {
+				t.Errorf("MissingFields got %v, want %d entries", got, tc.wantMissingLen)
+			}
+		})
+	}
+}
+
+func TestProbePath(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{"a":{"b":{"c":1}},"n":null,"s":"str"}`)
+
+	got, ok := probePath(raw, "a", "b", "c")
+	if !ok || string(got) != "1" {
+		t.Errorf("probePath a.b.c got (%s, %v), want (1, true)", got, ok)
+	}
+	for _, path := range [][]string{{"a", "x"}, {"n"}, {"s", "x"}} {
+		if _, ok := probePath(raw, path...); ok {
+			t.Errorf("probePath %v should not be found", path)
+		}
+	}
+
+	if got := probeMissingFields([]byte(`{}`), detailItemPath); !reflect.DeepEqual(got, []string{"props.pageProps.initialState.item.detail.item"}) {
+		t.Errorf("probeMissingFields got %v", got)
+	}
+}
+
+func TestParseNextData_parserChain(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		head      string
+		wantTitle string
+		wantErr   bool
+	}{
+		{
+			name:      "next data",
+			head:      `<script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"next"}}}}}}}</script>`,
+			wantTitle: "next",
+		},
+		{
+			name:      "window initial state",
+			head:      `<script>window.__INITIAL_STATE__ = {"item":{"detail":{"item":{"title":"window"}}}};</script>`,
+			wantTitle: "window",
+		},
+		{
+			name: "next data with unexpected shape falls through to window state",
+			head: `<script id="__NEXT_DATA__">{"props":{}}</script>` +
+				`<script>window.__PRELOADED_STATE__={"item":{"detail":{"item":{"title":"preloaded"}}}}</script>`,
+			wantTitle: "preloaded",
+		},
+		{
+			name:      "unexpected shape only",
+			head:      `<script id="__NEXT_DATA__">{"props":{}}</script>`,
+			wantTitle: "",
+		},
+		{
+			name:    "no embedded state",
+			head:    `<script>console.log("hello")</script>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><head>` + tc.head + `</head><body></body></html>`))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			data, err := ParseNextData(doc)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := data.DetailItem().Title; got != tc.wantTitle {
+				t.Fatalf("Title got %q, want %q", got, tc.wantTitle)
+			}
+		})
+	}
+}
+
+func TestParseNextData_schemaVariants(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		json          string
+		wantSchema    string
+		wantTitle     string
+		wantRecommend int
+		wantHasDetail bool
+	}{
+		{
+			name:          "initial state",
+			json:          `{"buildId":"b1","props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"state"}}},"recommend":{"items":[{"auctionId":"r1"}]}}}}}`,
+			wantSchema:    "initial_state",
+			wantTitle:     "state",
+			wantRecommend: 1,
+			wantHasDetail: true,
+		},
+		{
+			name:          "page props",
+			json:          `{"buildId":"b2","props":{"pageProps":{"item":{"title":"props"},"recommend":{"items":[{"auctionId":"r1"},{"auctionId":"r2"}]}}}}`,
+			wantSchema:    "page_props",
+			wantTitle:     "props",
+			wantRecommend: 2,
+			wantHasDetail: true,
+		},
+		{
+			name:          "unknown",
+			json:          `{"props":{"pageProps":{}}}`,
+			wantSchema:    "initial_state",
+			wantHasDetail: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := unmarshalNextData([]byte(tc.json))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := data.Schema(); got != tc.wantSchema {
+				t.Errorf("Schema got %q, want %q", got, tc.wantSchema)
+			}
+			if got := data.DetailItem().Title; got != tc.wantTitle {
+				t.Errorf("Title got %q, want %q", got, tc.wantTitle)
+			}
+			if got := len(data.RecommendItems()); got != tc.wantRecommend {
+				t.Errorf("RecommendItems got %d, want %d", got, tc.wantRecommend)
+			}
+			if got := data.HasDetailItem(); got != tc.wantHasDetail {
+				t.Errorf("HasDetailItem got %v, want %v", got, tc.wantHasDetail)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package yahoo
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultItemBaseURL は商品詳細ページのデフォルトのベースURLです
+	defaultItemBaseURL = "https://page.auctions.yahoo.co.jp"
+	// defaultCategoryBaseURL はカテゴリ一覧ページのデフォルトのベースURLです
+	defaultCategoryBaseURL = "https://auctions.yahoo.co.jp"
+	// defaultTimeout はHTTPクライアントのデフォルトのタイムアウトです
+	defaultTimeout = 30 * time.Second
+)
+
+// Option はスクレイパーの設定を変更する関数です
+type Option func(*options)
+
+// options はスクレイパーの設定値です
+type options struct {
+	client  *http.Client
+	baseURL string
+	tracer  trace.Tracer
+	meter   metric.Meter
+	retry   retryPolicy
+
+	validators *validatorCache
+	solver     ChallengeSolver
+
+	mobileFallback bool
+	mobileBaseURL  string
+
+	closedFallback bool
+	closedBaseURL  string
+
+	normalizeText bool
+	skipImages    bool
+	debugDumpDir  string
+	verifyHasNext bool
+
+	now func() time.Time // 残り時間から終了日時を求める際の現在時刻（テストで差し替える）
+
+	fieldExtractors []namedExtractor
+	imageEnricher   ImageEnricher
+
+	healthWindow  int
+	healthMinRate float64
+	healthMaxAge  time.Duration
+
+	coalesce bool
+}
+
+// newOptions はデフォルト値に opts を適用した設定値を返します
+func newOptions(defaultBaseURL string, opts []Option) *options {
+	o := &options{
+		client:  &http.Client{Timeout: defaultTimeout},
+		baseURL: defaultBaseURL,
+		tracer:  defaultTracer(),
+		meter:   defaultMeter(),
+		retry:   defaultRetryPolicy(),
+
+		mobileBaseURL: defaultMobileItemBaseURL,
+		closedBaseURL: defaultClosedItemBaseURL,
+
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithBaseURL はリクエスト先のベースURLを変更します
+// ミラーやステージング環境、httptest サーバーに向ける場合に利用します
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient はリクエストに利用する http.Client を変更します
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithTracerProvider はスクレイピング処理のスパンを記録する TracerProvider を設定します
+// 指定しない場合はスパンを記録しません（no-op）
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// WithMaxRetryAfter は 429 応答の Retry-After に従って待機する時間の上限を変更します
+// Retry-After がこれより長い場合も、この時間だけ待機して再試行します
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(o *options) {
+		o.retry.maxRetryAfter = d
+	}
+}
+
+// WithConditionalRequests は ETag / Last-Modified による条件付きリクエストを有効にします
+// 304 Not Modified の場合は前回取得したHTMLから結果を構築するため、ポーリング時の転送量を削減できます
+func WithConditionalRequests() Option {
+	return func(o *options) {
+		o.validators = newValidatorCache()
+	}
+}
+
+// WithMobileFallback はデスクトップ版の商品詳細ページから抽出できなかった場合に、
+// モバイル版ページ（モバイルのUser-Agent）から取得し直すフォールバックを有効にします
+// 失敗時のリクエストが倍になるため、デフォルトでは無効です
+func WithMobileFallback() Option {
+	return func(o *options) {
+		o.mobileFallback = true
+	}
+}
+
+// WithClosedAuctionFallback は商品詳細ページが 404 / 410 を返した場合に、
+// 終了済みオークションのアーカイブページから取得し直すフォールバックを有効にします
+// 終了後しばらく経った商品の落札価格・終了日時を取得したい場合に利用します。デフォルトでは無効です
+func WithClosedAuctionFallback() Option {
+	return func(o *options) {
+		o.closedFallback = true
+	}
+}
+
+// WithMeterProvider はフォールバック回数などのメトリクスを記録する MeterProvider を設定します
+// 指定しない場合はメトリクスを記録しません（no-op）
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meter = mp.Meter(tracerName)
+	}
+}
+
+// WithTextNormalization は商品のタイトルと説明文（テキスト）に NFKC 正規化を適用します
+// 全角・半角の違いだけの表記揺れを吸収したい場合に利用します。正規化前の値も保持されます
+func WithTextNormalization() Option {
+	return func(o *options) {
+		o.normalizeText = true
+	}
+}
+
+// WithSkipImages は商品詳細の画像の抽出（埋め込みJSONの画像一覧と、HTMLからのフォールバック）を常に省略します
+// フィールド指定で ItemFieldImages を含めた場合も Images は空になります。メイン画像（Thumbnail）は抽出コストが小さいため設定されます
+// 画像を利用しない用途で、抽出処理を省略するために利用します
+func WithSkipImages() Option {
+	return func(o *options) {
+		o.skipImages = true
+	}
+}
+
+// WithDebugDump は取得したHTMLと __NEXT_DATA__ のJSONを dir に書き出すデバッグモードを有効にします
+// ヤフオク側のHTML構造の変更を調査する用途です。指定しない場合は書き出しません
+func WithDebugDump(dir string) Option {
+	return func(o *options) {
+		o.debugDumpDir = dir
+	}
+}
+
+// WithHasNextVerification はカテゴリ一覧の HasNext を、取得件数が1ページの上限に達したかではなく
+// 取得位置 + 件数 < 総件数 で判定します。総件数を取得できなかった場合は従来の判定に戻ります
+func WithHasNextVerification() Option {
+	return func(o *options) {
+		o.verifyHasNext = true
+	}
+}
+
+// WithFieldExtractor は商品詳細の抽出パイプラインのうち、field の処理を e に差し替えます
+// field に既定のフィールド名（FieldDescription など）以外を指定した場合は、パイプラインの末尾に追加します
+// ヤフオク側のHTML構造の変更で一部のフィールドだけが取得できなくなった場合に、スクレイパー全体を変更せずに対応できます
+func WithFieldExtractor(field string, e FieldExtractor) Option {
+	return func(o *options) {
+		o.fieldExtractors = append(o.fieldExtractors, namedExtractor{field: field, extractor: e})
+	}
+}
+
+// WithRequestCoalescing は同じオークションIDの商品詳細を同時に取得する呼び出しをまとめ、ヤフオクへのリクエストを1回にします
+// 注目の商品にアクセスが集中した場合の負荷を抑えるために利用します。結果とエラーはまとめた呼び出し元のすべてに返します
+// 呼び出し元の1つがキャンセルされても、同じ結果を待つ他の呼び出し元のために取得は続けます
+func WithRequestCoalescing() Option {
+	return func(o *options) {
+		o.coalesce = true
+	}
+}
+
+// WithExtractionHealth は直近 window 件の商品詳細の取得のうち、主経路（デスクトップ版ページのJSON）から
+// タイトルを抽出できた割合が minRate を下回った場合に、HealthProvider の Health で不健全と判定します
+// maxAge より古い取得は判定に使わないため、取得が止まった後は時間の経過で健全な状態に戻ります（0 の場合は期限なし）
+// ページの取得自体に失敗した場合（ネットワークエラーや 404 など）は記録しません。指定しない場合は常に健全と判定します
+func WithExtractionHealth(window int, minRate float64, maxAge time.Duration) Option {
+	return func(o *options) {
+		o.healthWindow = window
+		o.healthMinRate = minRate
+		o.healthMaxAge = maxAge
+	}
+}
+
+// WithImageEnricher は FetchByID で商品を取得した後に、画像のURLからメタデータを付与する処理を設定します
+// 代表色でのグルーピングなど、画像ライブラリに依存する処理を利用側で差し込むために利用します。指定しない場合は何もしません
+func WithImageEnricher(e ImageEnricher) Option {
+	return func(o *options) {
+		o.imageEnricher = e
+	}
+}
+
+// WithChallengeSolver はCAPTCHAなどの確認ページが返された場合に、確認を通過するための処理を設定します
+// 通過の結果（Cookie・ヘッダー）を付けて1回だけ再試行します
+// 指定しない場合、確認ページは repository.ErrChallengeRequired として返されます
+func WithChallengeSolver(s ChallengeSolver) Option {
+	return func(o *options) {
+		o.solver = s
+	}
+}
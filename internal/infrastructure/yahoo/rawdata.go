@@ -0,0 +1,39 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RawNextDataProvider は商品詳細ページに埋め込まれたJSONをそのまま返すスクレイパーが実装するインターフェースです
+// Item にマッピングされていないフィールドを利用側で取り出すために利用します
+// NewYahooScraper はリポジトリのインターフェースを返すため、型アサーションで取得します
+type RawNextDataProvider interface {
+	FetchRawNextData(ctx context.Context, auctionID string) (json.RawMessage, error)
+}
+
+// FetchRawNextData は商品詳細ページの __NEXT_DATA__ のJSONを加工せずに返します
+// JSONの構造はヤフオク側の実装に依存し、予告なく変わることがあります
+// 商品情報の抽出は行わないため、FetchByID の応答に含めるよりも必要な場合だけ呼び出す方が転送量を抑えられます
+func (s *yahooScraper) FetchRawNextData(ctx context.Context, auctionID string) (raw json.RawMessage, err error) {
+	url := fmt.Sprintf("%s/jp/auction/%s", s.baseURL, auctionID)
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchRawNextData", attrAuctionID.String(auctionID), attrURL.String(url))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	doc, err := fetchHTML(ctx, s.client, url, s.retry, s.validators, s.solver)
+	if err != nil {
+		return nil, err
+	}
+
+	nextData, err := ParseNextData(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse next data: %w", err)
+	}
+	return nextData.Raw(), nil
+}
@@ -0,0 +1,69 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestYahooScraper_FetchRawNextData(t *testing.T) {
+	t.Parallel()
+
+	const nextData = `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"商品","unmappedField":{"a":1}}}}}}}}`
+
+	cases := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "next data",
+			body: `<html><head><script id="__NEXT_DATA__">` + nextData + `</script></head></html>`,
+			want: nextData,
+		},
+		{
+			name:    "not found",
+			body:    `<html><body></body></html>`,
+			wantErr: ErrNextDataNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+			provider, ok := repo.(RawNextDataProvider)
+			if !ok {
+				t.Fatalf("%T does not implement RawNextDataProvider", repo)
+			}
+
+			got, err := provider.FetchRawNextData(context.Background(), "x1234567890")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+			if !json.Valid(got) {
+				t.Errorf("got invalid json: %s", got)
+			}
+		})
+	}
+}
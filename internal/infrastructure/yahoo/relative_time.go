@@ -0,0 +1,53 @@
+package yahoo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeTimePattern は「残り 1日 3時間」などの残り時間の各単位（数値 + 単位）にマッチします
+var relativeTimePattern = regexp.MustCompile(`([0-9]+)\s*(日|時間|分|秒)`)
+
+// ErrRelativeTimeNotFound は残り時間の表記が見つからない場合のエラーです
+var ErrRelativeTimeNotFound = errors.New("relative time not found")
+
+// relativeTimeUnits は残り時間の単位と time.Duration の対応です
+var relativeTimeUnits = map[string]time.Duration{
+	"日":  24 * time.Hour,
+	"時間": time.Hour,
+	"分":  time.Minute,
+	"秒":  time.Second,
+}
+
+// parseRelativeTime は「残り 1日」「残り 3時間」「残り 15分」などの残り時間の表記を、now を基準とした終了日時に変換します
+// 「残り 1日 3時間」のように複数の単位を含む場合は合算します。全角数字にも対応します
+// 一覧の表示は丸められているため、得られる終了日時は目安です
+func parseRelativeTime(text string, now time.Time) (time.Time, error) {
+	normalized := normalizeDigits(strings.TrimSpace(text))
+	matches := relativeTimePattern.FindAllStringSubmatch(normalized, -1)
+	if len(matches) == 0 {
+		return time.Time{}, fmt.Errorf("%w in %q", ErrRelativeTimeNotFound, text)
+	}
+
+	var remaining time.Duration
+	for _, m := range matches {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %w", text, err)
+		}
+		remaining += time.Duration(n) * relativeTimeUnits[m[2]]
+	}
+	return now.Add(remaining), nil
+}
+
+// currentTime は now が設定されていればその値を、なければ time.Now() を返します
+func currentTime(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
@@ -0,0 +1,51 @@
+package yahoo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 29, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	cases := []struct {
+		name    string
+		text    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "days", text: "残り 1日", want: now.Add(24 * time.Hour)},
+		{name: "hours", text: "残り 3時間", want: now.Add(3 * time.Hour)},
+		{name: "minutes", text: "残り 15分", want: now.Add(15 * time.Minute)},
+		{name: "seconds", text: "残り30秒", want: now.Add(30 * time.Second)},
+		{name: "days and hours", text: "残り 2日 5時間", want: now.Add(53 * time.Hour)},
+		{name: "full-width digits", text: "残り １５分", want: now.Add(15 * time.Minute)},
+		{name: "surrounding whitespace", text: "\n  残り 3時間\n", want: now.Add(3 * time.Hour)},
+		{name: "finished", text: "終了", wantErr: true},
+		{name: "empty", text: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRelativeTime(tc.text, now)
+			if tc.wantErr {
+				if !errors.Is(err, ErrRelativeTimeNotFound) {
+					t.Fatalf("err got %v, want %v", err, ErrRelativeTimeNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
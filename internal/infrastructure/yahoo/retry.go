@@ -0,0 +1,81 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultMaxRetries は 429 応答時に再試行する最大回数です
+	defaultMaxRetries = 2
+	// defaultMaxRetryAfter は Retry-After に従って待機する時間の上限です
+	defaultMaxRetryAfter = 30 * time.Second
+)
+
+// retryPolicy は 429 (Too Many Requests) 応答時の再試行の設定です
+type retryPolicy struct {
+	maxRetries    int           // 再試行の最大回数。0の場合は再試行しない
+	maxRetryAfter time.Duration // Retry-After の待機時間の上限
+}
+
+// defaultRetryPolicy はデフォルトの再試行設定です
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxRetries:    defaultMaxRetries,
+		maxRetryAfter: defaultMaxRetryAfter,
+	}
+}
+
+// capWait は待機時間を上限以内に丸めます
+func (p retryPolicy) capWait(d time.Duration) time.Duration {
+	if d > p.maxRetryAfter {
+		return p.maxRetryAfter
+	}
+	return d
+}
+
+// parseRetryAfter は Retry-After ヘッダーの値を待機時間に変換します
+// 秒数（"120"）と HTTP-date（"Wed, 21 Oct 2015 07:28:00 GMT"）の両方の形式に対応します
+// 解釈できない場合は false を返します。過去の日時は待機なし（0）として扱います
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// sleepContext は d だけ待機します。待機中に ctx がキャンセルされた場合はそのエラーを返します
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
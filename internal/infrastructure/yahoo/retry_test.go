@@ -0,0 +1,141 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "120", want: 120 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "http date", value: "Fri, 02 Jan 2026 03:04:15 GMT", want: 10 * time.Second, wantOK: true},
+		{name: "past http date", value: "Fri, 02 Jan 2026 03:00:00 GMT", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-1", wantOK: false},
+		{name: "empty", value: "", wantOK: false},
+		{name: "invalid", value: "soon", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tc.value, now)
+			if ok != tc.wantOK || got != tc.want {
+				t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_capWait(t *testing.T) {
+	t.Parallel()
+
+	p := retryPolicy{maxRetryAfter: 5 * time.Second}
+	if got := p.capWait(time.Minute); got != 5*time.Second {
+		t.Fatalf("capWait(1m) got %v, want 5s", got)
+	}
+	if got := p.capWait(time.Second); got != time.Second {
+		t.Fatalf("capWait(1s) got %v, want 1s", got)
+	}
+}
+
+func TestFetchHTML_retriesAfterTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// 上限で丸められるため、実際には長時間待たない
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`<html><body>ok</body></html>`))
+	}))
+	defer srv.Close()
+
+	policy := retryPolicy{maxRetries: 2, maxRetryAfter: time.Millisecond}
+	if _, err := fetchHTML(context.Background(), srv.Client(), srv.URL, policy, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls got %d, want 2", got)
+	}
+}
+
+func TestFetchHTML_tooManyRequestsFailures(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		retryAfter string
+		policy     retryPolicy
+		wantCalls  int32
+	}{
+		{name: "retries exhausted", retryAfter: "0", policy: retryPolicy{maxRetries: 2, maxRetryAfter: time.Second}, wantCalls: 3},
+		{name: "no retry-after", retryAfter: "", policy: retryPolicy{maxRetries: 2, maxRetryAfter: time.Second}, wantCalls: 1},
+		{name: "retry disabled", retryAfter: "0", policy: retryPolicy{}, wantCalls: 1},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer srv.Close()
+
+			if _, err := fetchHTML(context.Background(), srv.Client(), srv.URL, tc.policy, nil, nil); err == nil {
+				t.Fatalf("expected error")
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Fatalf("calls got %d, want %d", got, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_retryWaitRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := fetchHTML(ctx, srv.Client(), srv.URL, retryPolicy{maxRetries: 1, maxRetryAfter: time.Minute}, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("wait was not interrupted: %v", elapsed)
+	}
+}
@@ -2,12 +2,18 @@ package yahoo
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
 	"jo3qma.com/yahoo_auctions/internal/domain/repository"
 )
@@ -18,38 +24,182 @@ import (
 type yahooScraper struct {
 	client  *http.Client
 	baseURL string
+	tracer  trace.Tracer
+	retry   retryPolicy
+
+	validators *validatorCache // nil の場合は条件付きリクエストを送信しない
+	solver     ChallengeSolver // nil の場合は確認ページを通過せずにエラーとする
+
+	// fallbacks はJSONに値がなくHTMLから取得したフィールドの回数です
+	fallbacks metric.Int64Counter
+
+	mobileFallback bool   // デスクトップ版での抽出に失敗した場合にモバイル版ページを試すか
+	mobileBaseURL  string // モバイル版ページのベースURL
+
+	closedFallback bool   // 商品詳細ページが 404 / 410 の場合に終了済みオークションのページを試すか
+	closedBaseURL  string // 終了済みオークションのページのベースURL
+
+	normalizeText bool   // タイトル・説明文に NFKC 正規化を適用するか
+	skipImages    bool   // true の場合、フィールド指定に関わらず画像の一覧を抽出しない
+	debugDumpDir  string // 空でない場合、取得したHTMLをこのディレクトリに書き出す
+
+	now func() time.Time // 残り時間の表記から終了日時を求める際の現在時刻
+
+	extractors    []namedExtractor // JSONのマッピング後に実行するフィールドの抽出パイプライン
+	imageEnricher ImageEnricher    // nil でない場合、取得した商品の画像からメタデータを付与する
+
+	stats  *requestStats     // リクエスト数と最後に成功した日時
+	health *extractionHealth // 直近の抽出の成功率。nil の場合は記録しない
+
+	flights *singleflight.Group // nil でない場合、同じ商品を同時に取得する呼び出しをまとめる
 }
 
 // NewYahooScraper は新しいYahooScraperインスタンスを作成します
-func NewYahooScraper() repository.ItemRepository {
-	return newYahooScraper(
-		&http.Client{Timeout: 30 * time.Second},
-		"https://page.auctions.yahoo.co.jp",
-	)
+// opts でベースURLや http.Client を変更できます
+func NewYahooScraper(opts ...Option) repository.ItemRepository {
+	return newYahooScraper(newOptions(defaultItemBaseURL, opts))
 }
 
 // newYahooScraper はテスト容易性のための内部コンストラクタです。
-// 本番コードは NewYahooScraper を利用し、テストでは http.Client/baseURL を注入します。
-func newYahooScraper(client *http.Client, baseURL string) repository.ItemRepository {
+// 本番コードは NewYahooScraper を利用し、テストでは http.Client/baseURL などを注入します。
+func newYahooScraper(o *options) repository.ItemRepository {
 	return &yahooScraper{
-		client:  client,
-		baseURL: baseURL,
+		client:  o.client,
+		baseURL: o.baseURL,
+		tracer:  o.tracer,
+		retry:   o.retry,
+
+		validators: o.validators,
+		solver:     o.solver,
+
+		fallbacks: newFallbackCounter(o.meter),
+
+		mobileFallback: o.mobileFallback,
+		mobileBaseURL:  o.mobileBaseURL,
+
+		closedFallback: o.closedFallback,
+		closedBaseURL:  o.closedBaseURL,
+
+		normalizeText: o.normalizeText,
+		skipImages:    o.skipImages,
+		debugDumpDir:  o.debugDumpDir,
+
+		now: o.now,
+
+		extractors:    buildFieldExtractors(o.fieldExtractors),
+		imageEnricher: o.imageEnricher,
+
+		stats:  &requestStats{},
+		health: newExtractionHealth(o.healthWindow, o.healthMinRate, o.healthMaxAge),
+
+		flights: newFlightGroup(o.coalesce),
+	}
+}
+
+// newFlightGroup は enabled の場合に呼び出しをまとめる singleflight.Group を作成します。無効の場合は nil を返します
+func newFlightGroup(enabled bool) *singleflight.Group {
+	if !enabled {
+		return nil
 	}
+	return &singleflight.Group{}
+}
+
+// Stats はこのスクレイパーのリクエスト数と最後に成功した日時を返します
+func (s *yahooScraper) Stats() Stats {
+	return s.stats.snapshot()
+}
+
+// Health はこのスクレイパーの直近の抽出の成功率から求めた健全性を返します
+func (s *yahooScraper) Health() Health {
+	return s.health.snapshot()
 }
 
 // FetchByID は指定されたオークションIDから商品情報を取得します
 func (s *yahooScraper) FetchByID(ctx context.Context, auctionID string) (*model.Item, error) {
+	return s.FetchByIDWithFields(ctx, auctionID, model.ItemFieldsAll)
+}
+
+// FetchByIDWithFields は fields で指定した任意フィールドのみを抽出して商品情報を取得します
+// WithRequestCoalescing が有効な場合、同じオークションIDとフィールドの同時の呼び出しは1回の取得にまとめます
+func (s *yahooScraper) FetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	if s.flights == nil {
+		return s.fetchByIDWithFields(ctx, auctionID, fields)
+	}
+
+	key := fmt.Sprintf("%s:%d", auctionID, fields)
+	ch := s.flights.DoChan(key, func() (any, error) {
+		// 最初の呼び出し元がキャンセルされても、同じ結果を待つ他の呼び出し元のために取得を続ける
+		return s.fetchByIDWithFields(context.WithoutCancel(ctx), auctionID, fields)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		item := res.Val.(*model.Item)
+		if res.Shared {
+			// 呼び出し元が出品者や画像などの入れ子のフィールドを書き換えても互いに影響しないよう、それぞれに複製を返す
+			item = item.Clone()
+		}
+		return item, nil
+	}
+}
+
+// fetchByIDWithFields は FetchByIDWithFields の実体で、呼び出しをまとめずに商品情報を取得します
+func (s *yahooScraper) fetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (item *model.Item, err error) {
+	if s.skipImages {
+		fields &^= model.ItemFieldImages
+	}
+
 	// オークションIDからURLを構築
 	url := fmt.Sprintf("%s/jp/auction/%s", s.baseURL, auctionID)
 
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchByID", attrAuctionID.String(auctionID), attrURL.String(url))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+	// フォールバックで取得した場合も含め、画像を要求されて取得に成功した商品にメタデータを付与する
+	defer func() {
+		if err == nil && fields.Has(model.ItemFieldImages) {
+			s.enrichImages(ctx, item)
+		}
+	}()
+
 	// 共通関数でHTML取得
-	doc, err := fetchHTML(ctx, s.client, url)
+	doc, err := fetchHTML(ctx, s.client, url, s.retry, s.validators, s.solver)
+	if err != nil && s.closedFallback && errors.Is(err, repository.ErrNotFound) {
+		// 終了後しばらく経った商品は通常のURLでは取得できないため、アーカイブページから取得し直す
+		log.Printf("warning: %s is not available on the live page, trying closed auction page: %v", auctionID, err)
+		recordFallback(ctx, s.fallbacks, auctionID, "closed_page")
+		closedItem, closedErr := s.fetchClosedItem(ctx, auctionID, fields)
+		if closedErr == nil {
+			return closedItem, nil
+		}
+		return nil, errors.Join(err, fmt.Errorf("closed fallback: %w", closedErr))
+	}
 	if err != nil {
 		return nil, err
 	}
+	dumpDocument(s.debugDumpDir, "auction", auctionID, doc, time.Now())
 
 	// HTMLから商品情報を抽出
-	item, err := s.extractItemInfo(doc, auctionID)
+	item, fromNextData, err := s.extractItemInfoWithSource(ctx, doc, auctionID, fields)
+	// ページを取得できた場合のみ、主経路での抽出に成功したか（フォールバックを使わずにタイトルを得られたか）を記録する
+	s.health.record(err == nil && fromNextData && item.Title != "")
+	if err != nil && s.mobileFallback {
+		// デスクトップ版の構造変更に備え、モバイル版ページから取得し直す
+		log.Printf("warning: failed to extract %s from desktop page, trying mobile page: %v", auctionID, err)
+		recordFallback(ctx, s.fallbacks, auctionID, "mobile_page")
+		mobileItem, mobileErr := s.fetchMobileItem(ctx, auctionID, fields)
+		if mobileErr == nil {
+			return mobileItem, nil
+		}
+		err = errors.Join(err, fmt.Errorf("mobile fallback: %w", mobileErr))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract item info: %w", err)
 	}
@@ -57,80 +207,286 @@ func (s *yahooScraper) FetchByID(ctx context.Context, auctionID string) (*model.
 	return item, nil
 }
 
+// FetchStatus は指定されたオークションIDの状態のみを取得します
+// ステータスはページ内のJSONにしか含まれないためページ自体は取得しますが、その他の抽出は行いません
+func (s *yahooScraper) FetchStatus(ctx context.Context, auctionID string) (status model.Status, err error) {
+	url := fmt.Sprintf("%s/jp/auction/%s", s.baseURL, auctionID)
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchStatus", attrAuctionID.String(auctionID), attrURL.String(url))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	doc, err := fetchHTML(ctx, s.client, url, s.retry, s.validators, s.solver)
+	if err != nil {
+		return model.StatusUnspecified, err
+	}
+
+	nextData, err := ParseNextData(doc)
+	if err != nil {
+		return model.StatusUnspecified, fmt.Errorf("failed to parse next data: %w", err)
+	}
+	return statusFromJSON(nextData.DetailItem().Status), nil
+}
+
 // extractItemInfo はHTMLドキュメントから商品情報を抽出します
-// Next.jsのJSONデータを優先して使用し、取得できない場合はエラーを返します
-func (s *yahooScraper) extractItemInfo(doc *goquery.Document, auctionID string) (*model.Item, error) {
+// Next.jsのJSONデータを優先して使用し、JSONに値がないフィールドはメタデータやHTMLから取得します
+// JSONがなく、タイトルのメタデータ（og:title）もない場合は商品ページとみなさずエラーを返します
+func (s *yahooScraper) extractItemInfo(ctx context.Context, doc *goquery.Document, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	item, _, err := s.extractItemInfoWithSource(ctx, doc, auctionID, fields)
+	return item, err
+}
+
+// extractItemInfoWithSource は extractItemInfo と同じ抽出を行い、埋め込みJSONを利用できたかもあわせて返します
+func (s *yahooScraper) extractItemInfoWithSource(ctx context.Context, doc *goquery.Document, auctionID string, fields model.ItemFields) (*model.Item, bool, error) {
 	// JSONデータをパース
-	nextData, err := s.parseNextData(doc)
+	nextData, err := ParseNextData(doc)
+	fromNextData := err == nil
+	var metaTitle string
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse next data: %w", err)
+		// JSONの埋め込み方が変わった場合も、メタデータとHTMLから取得できるフィールドは抽出する
+		metaTitle = ogTitle(doc)
+		if metaTitle == "" {
+			return nil, false, fmt.Errorf("failed to parse next data: %w", err)
+		}
+		log.Printf("warning: next data for %s is not available, extracting from page metadata and html: %v", auctionID, err)
+		recordFallback(ctx, s.fallbacks, auctionID, "next_data")
+		nextData = &NextData{}
+	} else if !nextData.HasDetailItem() {
+		// JSONとしては正しいが想定と形が異なる場合、各フィールドはゼロ値になるため警告を出す
+		log.Printf("warning: next data for %s has unexpected shape: item detail not found (build %q)", auctionID, nextData.BuildID)
+	} else if missing := nextData.MissingFields(); len(missing) > 0 {
+		log.Printf("warning: next data for %s (%s schema) is missing expected fields: %s", auctionID, nextData.Schema(), strings.Join(missing, ", "))
 	}
 
 	// JSONからモデルへのマッピング
-	item := s.extractItemFromJSON(nextData, auctionID)
-	return item, nil
+	item := s.extractItemFromJSONWithFields(nextData, auctionID, fields)
+	if metaTitle != "" {
+		item.Title = metaTitle
+	}
+
+	// 以下はJSONに値がない場合にHTMLから取得する。HTMLにだけ値がある場合は
+	// JSONスキーマ変更の兆候のため、フォールバックとして記録する
+	in := &ExtractInput{
+		AuctionID: auctionID,
+		Doc:       doc,
+		NextData:  nextData,
+		Fields:    fields,
+		Now:       currentTime(s.now),
+	}
+	for _, e := range s.fieldExtractors() {
+		if e.extractor.Extract(in, item) {
+			recordFallback(ctx, s.fallbacks, auctionID, e.field)
+		}
+	}
+	if item.Thumbnail == "" && len(item.Images) > 0 {
+		item.Thumbnail = item.Images[0]
+	}
+
+	if s.normalizeText {
+		applyTextNormalization(item)
+	}
+
+	// 入札件数はページ上の表記とも突き合わせ、食い違いがあればログに残す（値はJSONを優先）
+	if textCount, ok := bidCountFromText(doc); ok && textCount != item.BidCount {
+		log.Printf("warning: bid count mismatch for %s: json=%d text=%d", auctionID, item.BidCount, textCount)
+	}
+
+	return item, fromNextData, nil
+}
+
+// primaryImage はメイン画像として指定された画像のURLを返します
+// 指定がない場合は最初の画像、画像がない場合は空文字列を返します
+func primaryImage(imgs []NextDataImage) string {
+	first := ""
+	for _, img := range imgs {
+		if img.Image == "" {
+			continue
+		}
+		if img.IsMain {
+			return img.Image
+		}
+		if first == "" {
+			first = img.Image
+		}
+	}
+	return first
+}
+
+// fieldExtractors はフィールドの抽出パイプラインを返します。未設定の場合は既定のパイプラインです
+func (s *yahooScraper) fieldExtractors() []namedExtractor {
+	if s.extractors == nil {
+		return defaultFieldExtractors
+	}
+	return s.extractors
+}
+
+// applyTextNormalization はタイトルと説明文（テキスト）に NFKC 正規化を適用し、正規化前の値を Raw* に保持します
+func applyTextNormalization(item *model.Item) {
+	item.RawTitle = item.Title
+	item.Title = normalizeText(item.Title)
+
+	item.RawDescriptionText = item.DescriptionText
+	item.DescriptionText = normalizeText(item.DescriptionText)
+}
+
+// ogTitle はページのタイトル（meta[property="og:title"]）を返します。ない場合は空文字を返します
+func ogTitle(doc *goquery.Document) string {
+	return strings.TrimSpace(doc.Find(`meta[property="og:title"]`).First().AttrOr("content", ""))
+}
+
+// ogImage はページの代表画像（meta[property="og:image"]）のURLを返します。ない場合は空文字を返します
+func ogImage(doc *goquery.Document) string {
+	return strings.TrimSpace(doc.Find(`meta[property="og:image"]`).First().AttrOr("content", ""))
+}
+
+// otherInfoValue はHTMLの商品情報欄（見出しと値の組）から、見出しに label を含む項目の値を返します
+// th/td 形式のテーブルと dt/dd 形式の定義リストの両方に対応します。見つからない場合は空文字を返します
+func otherInfoValue(doc *goquery.Document, label string) string {
+	var value string
+	doc.Find("th, dt").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if !strings.Contains(s.Text(), label) {
+			return true
+		}
+		value = strings.TrimSpace(s.NextFiltered("td, dd").Text())
+		return value == ""
+	})
+	return value
+}
+
+// extractRelatedItemsFromHTML はHTMLの関連商品（おすすめ）欄から商品を抽出します
+// 関連商品欄がない場合は空スライスを返します
+func extractRelatedItemsFromHTML(doc *goquery.Document) []*model.CategoryItem {
+	items := make([]*model.CategoryItem, 0)
+
+	// 関連商品: section#recommend li (各商品のリンクに data-auction-id を持つ)
+	doc.Find("section#recommend li").Each(func(_ int, s *goquery.Selection) {
+		link := s.Find("a[data-auction-id]").First()
+		id, exists := link.Attr("data-auction-id")
+		if !exists || id == "" {
+			return
+		}
+
+		item := &model.CategoryItem{
+			AuctionID:    id,
+			Title:        strings.TrimSpace(link.AttrOr("title", link.Text())),
+			CurrentPrice: parsePrice(s.Find(".Price").First().Text()),
+		}
+		item.TotalPrice = item.CurrentPrice
+		if src, exists := s.Find("img").Attr("src"); exists {
+			item.Image = src
+		}
+		items = append(items, item)
+	})
+
+	return items
+}
+
+// descriptionTruncationMarker は説明文の一部のみが表示されている場合の展開リンクの文言です
+const descriptionTruncationMarker = "続きを見る"
+
+// descriptionFromHTML はHTMLの説明欄のHTMLを返します
+// 説明欄が「続きを見る」で折りたたまれている場合、HTMLには先頭部分しか含まれないため truncated を true にします
+func descriptionFromHTML(doc *goquery.Document) (desc string, truncated bool) {
+	// 説明欄: div.ProductExplanation__commentBody（古いページでは #description）
+	body := doc.Find("div.ProductExplanation__commentBody, #description").First()
+	if body.Length() == 0 {
+		return "", false
+	}
+	truncated = strings.Contains(body.Parent().Text(), descriptionTruncationMarker)
+
+	html, err := body.Html()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(html), truncated
+}
+
+// extractQuestionsFromHTML はHTMLの質問欄から質問と回答を抽出します
+// 質問欄がない場合は空スライスを返します。HTMLの日時は表示用のため取得しません
+func extractQuestionsFromHTML(doc *goquery.Document) []*model.QA {
+	questions := make([]*model.QA, 0)
+
+	// 質問欄: section#qanda li (各項目に .Question と .Answer を持つ)
+	doc.Find("section#qanda li").Each(func(_ int, s *goquery.Selection) {
+		question := strings.TrimSpace(s.Find(".Question").First().Text())
+		if question == "" {
+			return
+		}
+		questions = append(questions, &model.QA{
+			Question: question,
+			Answer:   strings.TrimSpace(s.Find(".Answer").First().Text()),
+		})
+	})
+
+	return questions
 }
 
-// NextData はNext.jsのJSON構造体です
-type NextData struct {
-	Props struct {
-		PageProps struct {
-			InitialState struct {
-				Item struct {
-					Detail struct {
-						Item struct {
-							Title                string `json:"title"`
-							Price                int64  `json:"price"`
-							TaxinPrice           int64  `json:"taxinPrice"`
-							Status               string `json:"status"`
-							DescriptionHtml      string `json:"descriptionHtml"`
-							InitPrice            int64  `json:"initPrice"`
-							TaxinStartPrice      int64  `json:"taxinStartPrice"`
-							StartTime            string `json:"startTime"` // ISO 8601
-							EndTime              string `json:"endTime"`   // ISO 8601
-							IsEarlyClosing       bool   `json:"isEarlyClosing"`
-							IsAutomaticExtension bool   `json:"isAutomaticExtension"`
-							ItemReturnable       struct {
-								Allowed bool   `json:"allowed"`
-								Comment string `json:"comment"`
-							} `json:"itemReturnable"`
-							Img []struct {
-								Image  string `json:"image"`
-								Width  int    `json:"width"`
-								Height int    `json:"height"`
-							} `json:"img"`
-						} `json:"item"`
-					} `json:"detail"`
-				} `json:"item"`
-			} `json:"initialState"`
-		} `json:"pageProps"`
-	} `json:"props"`
-}
-
-// parseNextData はHTMLからNext.jsのJSONデータを抽出・パースします
-func (s *yahooScraper) parseNextData(doc *goquery.Document) (*NextData, error) {
-	scriptContent := doc.Find("script#__NEXT_DATA__").Text()
-	if scriptContent == "" {
-		return nil, fmt.Errorf("next data script not found")
-	}
-
-	var data NextData
-	if err := json.Unmarshal([]byte(scriptContent), &data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal next data: %w", err)
-	}
-
-	return &data, nil
+// bidCountPattern は「このオークションには X件の入札があります」の件数部分にマッチします
+var bidCountPattern = regexp.MustCompile(`([0-9,]+)\s*件の入札があります`)
+
+// bidCountFromText はページ本文の入札件数の表記から件数を抽出します
+// 表記が見つからない場合は false を返します
+func bidCountFromText(doc *goquery.Document) (int64, bool) {
+	// __NEXT_DATA__ などのスクリプト内の文字列は対象外とする
+	body := doc.Find("body").Clone()
+	body.Find("script").Remove()
+
+	m := bidCountPattern.FindStringSubmatch(body.Text())
+	if m == nil {
+		return 0, false
+	}
+	return parseCount(m[1]), true
 }
 
 // extractItemFromJSON はNextDataからドメインモデルのItemを構築します
 func (s *yahooScraper) extractItemFromJSON(data *NextData, auctionID string) *model.Item {
-	itemData := data.Props.PageProps.InitialState.Item.Detail.Item
+	return s.extractItemFromJSONWithFields(data, auctionID, model.ItemFieldsAll)
+}
+
+// extractItemFromJSONWithFields はNextDataからドメインモデルのItemを構築します
+// fields に含まれない任意フィールド（説明文・画像）は抽出を省略します
+func (s *yahooScraper) extractItemFromJSONWithFields(data *NextData, auctionID string, fields model.ItemFields) *model.Item {
+	itemData := data.DetailItem()
 
 	item := &model.Item{
-		AuctionID:   auctionID,
-		Title:       itemData.Title,
-		Description: itemData.DescriptionHtml,
-		Images:      make([]string, 0, len(itemData.Img)),
+		AuctionID: auctionID,
+		Title:     itemData.Title,
+	}
+
+	// 商品説明
+	if fields.Has(model.ItemFieldDescription) {
+		item.Description = itemData.DescriptionHtml
+		item.DescriptionText = htmlToText(itemData.DescriptionHtml)
+	}
+
+	item.BidCount = itemData.Bids
+	item.ShippingDays = strings.TrimSpace(itemData.ShipSchedule)
+	item.ShippingSize = parseShippingSize(itemData.ShippingSize)
+	item.ShippingWeight = parseShippingWeight(itemData.ShippingWeight)
+	item.Condition = parseCondition(itemData.ConditionName)
+	item.ShipsInternationally = itemData.IsInternationalShipping
+	item.IsRelisted = itemData.IsRelisted != nil && *itemData.IsRelisted
+	item.ItemLocation = strings.TrimSpace(itemData.ItemLocation)
+	item.ProductCode = strings.TrimSpace(itemData.ProductCode)
+	item.BidRestriction = bidRestrictionFromJSON(itemData.BidRestriction)
+	item.Tags = normalizeTags(itemData.Tags)
+	item.WatchCount = itemData.WatchListNum
+	item.ViewingNowCount = itemData.ViewingNowNum
+
+	// 選択肢（ストア出品のサイズ・色など）
+	item.Variations = make([]*model.Variation, 0, len(itemData.Variations))
+	for _, v := range itemData.Variations {
+		name := strings.TrimSpace(v.Name)
+		if name == "" {
+			continue
+		}
+		price := v.TaxinPrice
+		if price <= 0 {
+			price = v.Price
+		}
+		item.Variations = append(item.Variations, &model.Variation{Name: name, Price: price, Stock: v.Stock})
 	}
 
 	// 価格
@@ -141,28 +497,96 @@ func (s *yahooScraper) extractItemFromJSON(data *NextData, auctionID string) *mo
 	}
 
 	// 画像
-	seenURLs := make(map[string]bool)
-	for _, img := range itemData.Img {
-		if !seenURLs[img.Image] {
-			item.Images = append(item.Images, img.Image)
-			seenURLs[img.Image] = true
+	if fields.Has(model.ItemFieldImages) {
+		item.Images = make([]string, 0, len(itemData.Img))
+		seenURLs := make(map[string]bool)
+		for _, img := range itemData.Img {
+			if img.Image != "" && !seenURLs[img.Image] {
+				item.Images = append(item.Images, img.Image)
+				seenURLs[img.Image] = true
+			}
+		}
+	}
+
+	// サムネイルは画像の一覧を取得しない場合でも設定する
+	item.Thumbnail = primaryImage(itemData.Img)
+
+	// 出品者
+	if fields.Has(model.ItemFieldSeller) {
+		seller := itemData.Seller
+		item.Seller = &model.Seller{
+			ID:               seller.AucUserID,
+			Name:             seller.DisplayName,
+			GoodRating:       seller.Rating.GoodRating,
+			BadRating:        seller.Rating.BadRating,
+			RatingPercentage: ratingPercentage(seller.Rating.GoodRating, seller.Rating.BadRating),
+			Location:         strings.TrimSpace(seller.Prefecture),
+		}
+	}
+
+	// クーポン
+	coupons := itemData.Promotion.Coupons
+	item.HasCoupon = len(coupons) > 0
+	titles := make([]string, 0, len(coupons))
+	for _, c := range coupons {
+		if title := strings.TrimSpace(c.Title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	item.CouponDescription = strings.Join(titles, " / ")
+
+	// 注目のオークション
+	item.IsFeatured = itemData.Promotion.IsFeatured
+
+	// 関連商品
+	if fields.Has(model.ItemFieldRelatedItems) {
+		recommended := data.RecommendItems()
+		item.RelatedItems = make([]*model.CategoryItem, 0, len(recommended))
+		for _, r := range recommended {
+			if r.AuctionID == "" || r.AuctionID == auctionID {
+				continue
+			}
+			item.RelatedItems = append(item.RelatedItems, &model.CategoryItem{
+				AuctionID:      r.AuctionID,
+				Title:          r.Title,
+				CurrentPrice:   r.Price,
+				TotalPrice:     r.Price, // 関連商品には送料の情報がない
+				ImmediatePrice: r.BuyNowPrice,
+				BidCount:       r.Bids,
+				Image:          r.ImageURL,
+			})
+		}
+	}
+
+	// 質問と回答
+	if fields.Has(model.ItemFieldQuestions) {
+		item.Questions = make([]*model.QA, 0, len(itemData.Questions))
+		for _, q := range itemData.Questions {
+			qa := &model.QA{
+				Question: strings.TrimSpace(q.Question),
+				Answer:   strings.TrimSpace(q.Answer),
+			}
+			if qa.Question == "" {
+				continue
+			}
+			if t, err := parseDateTime(q.QuestionTime); err == nil {
+				qa.AskedAt = t
+			}
+			if t, err := parseDateTime(q.AnswerTime); err == nil {
+				qa.AnsweredAt = t
+			}
+			item.Questions = append(item.Questions, qa)
 		}
 	}
 
 	// ステータス
-	switch itemData.Status {
-	case "open":
-		item.Status = model.StatusActive
-	case "closed":
-		item.Status = model.StatusFinished
-	case "cancel", "canceled":
-		item.Status = model.StatusCanceled
-	default:
-		// 終了済みとみなされるケースを確認
-		// 現在時刻と比較して終了していればFinishedとするなどのロジックも検討可能だが
-		// JSONのstatusを信頼する
-		item.Status = model.StatusUnspecified
+	item.Status = statusFromJSON(itemData.Status)
+
+	// 終了済みの場合、JSONの価格は入札中の価格ではなく落札価格を表す
+	if item.Status == model.StatusFinished {
+		item.FinalPrice = item.CurrentPrice
 	}
+	item.ImmediateBuyAvailable = immediateBuyAvailable(itemData, item.Status)
 
 	// オークション情報
 	info := &model.AuctionInformation{
@@ -171,6 +595,7 @@ func (s *yahooScraper) extractItemFromJSON(data *NextData, auctionID string) *mo
 		AutoExtension:    itemData.IsAutomaticExtension,
 		Returnable:       itemData.ItemReturnable.Allowed,
 		ReturnableDetail: itemData.ItemReturnable.Comment,
+		TotalAccessCount: itemData.TotalAccessCount,
 	}
 
 	// 開始価格
@@ -181,13 +606,82 @@ func (s *yahooScraper) extractItemFromJSON(data *NextData, auctionID string) *mo
 	}
 
 	// 時間パース (ISO 8601形式: "2025-12-29T16:00:10+09:00")
-	if t, err := time.Parse(time.RFC3339, itemData.StartTime); err == nil {
+	if t, err := parseDateTime(itemData.StartTime); err == nil {
 		info.StartTime = t
 	}
-	if t, err := time.Parse(time.RFC3339, itemData.EndTime); err == nil {
+	if t, err := parseDateTime(itemData.EndTime); err == nil {
 		info.EndTime = t
 	}
 
 	item.AuctionInfo = info
 	return item
 }
+
+// normalizeTags はタグの前後の空白を除去し、空のタグと重複を取り除きます
+// タグがない場合は空スライスを返します
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// immediateBuyAvailable は現時点で即決価格での落札ができるかを返します
+// JSONに即決の可否が含まれる場合はその値を、含まれない場合は即決価格があり入札がまだないことを条件とします
+// 出品中でない場合は常に false です
+func immediateBuyAvailable(itemData *NextDataItem, status model.Status) bool {
+	if status != model.StatusActive {
+		return false
+	}
+	if itemData.IsBidorbuyAvailable != nil {
+		return *itemData.IsBidorbuyAvailable
+	}
+	return max(itemData.TaxinBidorbuy, itemData.Bidorbuy) > 0 && itemData.Bids == 0
+}
+
+// bidRestrictionFromJSON は入札者の制限を BidRestriction に変換します
+// 両方が指定されている場合は、より厳しいプレミアム会員限定を優先します
+func bidRestrictionFromJSON(r NextDataBidRestriction) model.BidRestriction {
+	switch {
+	case r.IsPremiumOnly:
+		return model.BidRestrictionPremiumOnly
+	case r.IsVerifiedOnly:
+		return model.BidRestrictionVerifiedOnly
+	default:
+		return model.BidRestrictionNone
+	}
+}
+
+// statusFromJSON はJSONの status の値をドメインのStatusに変換します
+func statusFromJSON(status string) model.Status {
+	switch status {
+	case "open":
+		return model.StatusActive
+	case "closed":
+		return model.StatusFinished
+	case "cancel", "canceled":
+		return model.StatusCanceled
+	default:
+		// 終了済みとみなされるケースを確認
+		// 現在時刻と比較して終了していればFinishedとするなどのロジックも検討可能だが
+		// JSONのstatusを信頼する
+		return model.StatusUnspecified
+	}
+}
+
+// ratingPercentage は良い評価の割合（0〜100）を計算します
+// 評価が1件もない場合は0を返します
+func ratingPercentage(good, bad int64) float64 {
+	total := good + bad
+	if total <= 0 {
+		return 0
+	}
+	return float64(good) / float64(total) * 100
+}
@@ -1,6 +1,11 @@
 package yahoo
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -9,37 +14,62 @@ import (
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
 )
 
-func TestYahooScraper_parseNextData_returnsErrorWhenScriptMissing(t *testing.T) {
+func TestParseNextData_returnsErrorWhenScriptMissing(t *testing.T) {
 	t.Parallel()
 
-	s := &yahooScraper{}
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<html><head></head><body></body></html>"))
 	if err != nil {
 		t.Fatalf("failed to build doc: %v", err)
 	}
 
-	_, err = s.parseNextData(doc)
+	_, err = ParseNextData(doc)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
 }
 
-func TestYahooScraper_parseNextData_returnsErrorWhenJSONInvalid(t *testing.T) {
+func TestParseNextData_returnsErrorWhenJSONInvalid(t *testing.T) {
 	t.Parallel()
 
-	s := &yahooScraper{}
 	html := `<html><head><script id="__NEXT_DATA__">{invalid json}</script></head><body></body></html>`
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		t.Fatalf("failed to build doc: %v", err)
 	}
 
-	_, err = s.parseNextData(doc)
+	_, err = ParseNextData(doc)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
 }
 
+func TestParseNextData_parsesTypedFields(t *testing.T) {
+	t.Parallel()
+
+	html := `<html><head><script id="__NEXT_DATA__">` +
+		`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title","img":[{"image":"https://example.com/1.jpg"}]}}},` +
+		`"recommend":{"items":[{"auctionId":"r1"}]}}}}}` +
+		`</script></head><body></body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	data, err := ParseNextData(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := data.DetailItem().Title; got != "title" {
+		t.Fatalf("Title got %q, want %q", got, "title")
+	}
+	if got := data.DetailItem().Img; len(got) != 1 || got[0].Image != "https://example.com/1.jpg" {
+		t.Fatalf("Img got %#v", got)
+	}
+	if got := data.RecommendItems(); len(got) != 1 || got[0].AuctionID != "r1" {
+		t.Fatalf("RecommendItems got %#v", got)
+	}
+}
+
 func TestYahooScraper_extractItemFromJSON_mapsFields(t *testing.T) {
 	t.Parallel()
 
@@ -61,11 +91,9 @@ func TestYahooScraper_extractItemFromJSON_mapsFields(t *testing.T) {
 	item.IsAutomaticExtension = false
 	item.ItemReturnable.Allowed = true
 	item.ItemReturnable.Comment = "detail"
-	item.Img = []struct {
-		Image  string `json:"image"`
-		Width  int    `json:"width"`
-		Height int    `json:"height"`
-	}{
+	item.TotalAccessCount = 5678
+	item.ProductCode = " SKU-001 "
+	item.Img = []NextDataImage{
 		{Image: "https://example.com/1.jpg", Width: 1, Height: 1},
 		{Image: "https://example.com/1.jpg", Width: 1, Height: 1}, // duplicate
 		{Image: "https://example.com/2.jpg", Width: 1, Height: 1},
@@ -87,6 +115,9 @@ func TestYahooScraper_extractItemFromJSON_mapsFields(t *testing.T) {
 	if got.Status != model.StatusActive {
 		t.Fatalf("Status got %v, want %v", got.Status, model.StatusActive)
 	}
+	if got.ProductCode != "SKU-001" {
+		t.Fatalf("ProductCode got %q, want %q", got.ProductCode, "SKU-001")
+	}
 	if len(got.Images) != 2 {
 		t.Fatalf("Images len got %d, want %d", len(got.Images), 2)
 	}
@@ -112,6 +143,9 @@ func TestYahooScraper_extractItemFromJSON_mapsFields(t *testing.T) {
 	if got.AuctionInfo.ReturnableDetail != "detail" {
 		t.Fatalf("AuctionInfo.ReturnableDetail got %q, want %q", got.AuctionInfo.ReturnableDetail, "detail")
 	}
+	if got.AuctionInfo.TotalAccessCount != 5678 {
+		t.Fatalf("AuctionInfo.TotalAccessCount got %d, want %d", got.AuctionInfo.TotalAccessCount, 5678)
+	}
 
 	wantStart, err := time.Parse(time.RFC3339, "2025-12-29T16:00:10+09:00")
 	if err != nil {
@@ -129,6 +163,543 @@ func TestYahooScraper_extractItemFromJSON_mapsFields(t *testing.T) {
 	}
 }
 
+func TestYahooScraper_extractItemFromJSONWithFields_skipsUnrequestedFields(t *testing.T) {
+	t.Parallel()
+
+	s := &yahooScraper{}
+	data := &NextData{}
+	item := &data.Props.PageProps.InitialState.Item.Detail.Item
+	item.Title = "title"
+	item.TaxinPrice = 100
+	item.DescriptionHtml = "<p>desc</p>"
+	item.Img = []NextDataImage{
+		{Image: "https://example.com/1.jpg"},
+	}
+
+	got := s.extractItemFromJSONWithFields(data, "x1234567890", model.ItemFieldsNone)
+	if got.Description != "" {
+		t.Fatalf("Description got %q, want empty", got.Description)
+	}
+	if got.Images != nil {
+		t.Fatalf("Images got %#v, want nil", got.Images)
+	}
+	if got.Title != "title" || got.CurrentPrice != 100 {
+		t.Fatalf("core fields not populated: %+v", got)
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_mapsSeller(t *testing.T) {
+	t.Parallel()
+
+	s := &yahooScraper{}
+	data := &NextData{}
+	seller := &data.Props.PageProps.InitialState.Item.Detail.Item.Seller
+	seller.AucUserID = "seller1"
+	seller.DisplayName = "Seller"
+	seller.Rating.GoodRating = 99
+	seller.Rating.BadRating = 1
+
+	got := s.extractItemFromJSON(data, "x1234567890")
+	if got.Seller == nil {
+		t.Fatalf("Seller is nil")
+	}
+	if got.Seller.ID != "seller1" || got.Seller.Name != "Seller" {
+		t.Fatalf("Seller got %+v", got.Seller)
+	}
+	if got.Seller.GoodRating != 99 || got.Seller.BadRating != 1 {
+		t.Fatalf("Seller ratings got good=%d bad=%d, want good=99 bad=1", got.Seller.GoodRating, got.Seller.BadRating)
+	}
+	if got.Seller.RatingPercentage != 99 {
+		t.Fatalf("Seller.RatingPercentage got %v, want %v", got.Seller.RatingPercentage, 99.0)
+	}
+}
+
+func TestYahooScraper_extractItemInfo_relatedItems(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		json    string
+		html    string
+		wantIDs []string
+	}{
+		{
+			name:    "from json",
+			json:    `{"props":{"pageProps":{"initialState":{"recommend":{"items":[{"auctionId":"r1","title":"t1","price":100},{"auctionId":"x1234567890"},{"auctionId":"r2","buyNowPrice":300}]}}}}}`,
+			wantIDs: []string{"r1", "r2"},
+		},
+		{
+			name: "html fallback",
+			json: `{}`,
+			html: `<section id="recommend"><ul>
+				<li><a data-auction-id="h1" title="html item">html item</a><span class="Price">1,500円</span><img src="https://example.com/h1.jpg"></li>
+				<li><a>no id</a></li>
+			</ul></section>`,
+			wantIDs: []string{"h1"},
+		},
+		{
+			name:    "none",
+			json:    `{}`,
+			wantIDs: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head><body>` + tc.html + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.RelatedItems == nil {
+				t.Fatalf("RelatedItems is nil, want non-nil slice")
+			}
+
+			ids := make([]string, 0, len(got.RelatedItems))
+			for _, r := range got.RelatedItems {
+				ids = append(ids, r.AuctionID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.wantIDs, ",") {
+				t.Fatalf("RelatedItems IDs got %v, want %v", ids, tc.wantIDs)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_textNormalization(t *testing.T) {
+	t.Parallel()
+
+	html := `<html><head><script id="__NEXT_DATA__">` +
+		`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"ＮＩＫＯＮ　Ｆ３ ｶﾒﾗ","descriptionHtml":"<p>動作品（ＡＢランク）</p>"}}}}}}}` +
+		`</script></head><body></body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := &yahooScraper{}
+		got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Title != "ＮＩＫＯＮ　Ｆ３ ｶﾒﾗ" {
+			t.Fatalf("Title got %q", got.Title)
+		}
+		if got.DescriptionText != "動作品（ＡＢランク）" {
+			t.Fatalf("DescriptionText got %q", got.DescriptionText)
+		}
+		if got.RawTitle != "" || got.RawDescriptionText != "" {
+			t.Fatalf("Raw values should be empty when disabled: %q %q", got.RawTitle, got.RawDescriptionText)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		s := &yahooScraper{normalizeText: true}
+		got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Title != "NIKON F3 カメラ" {
+			t.Fatalf("Title got %q, want %q", got.Title, "NIKON F3 カメラ")
+		}
+		if got.RawTitle != "ＮＩＫＯＮ　Ｆ３ ｶﾒﾗ" {
+			t.Fatalf("RawTitle got %q", got.RawTitle)
+		}
+		if got.DescriptionText != "動作品(ABランク)" {
+			t.Fatalf("DescriptionText got %q, want %q", got.DescriptionText, "動作品(ABランク)")
+		}
+		if got.RawDescriptionText != "動作品（ＡＢランク）" {
+			t.Fatalf("RawDescriptionText got %q", got.RawDescriptionText)
+		}
+		if got.Description != "<p>動作品（ＡＢランク）</p>" {
+			t.Fatalf("Description (HTML) must stay raw, got %q", got.Description)
+		}
+	})
+}
+
+func TestYahooScraper_extractItemInfo_sellerLocation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want string
+	}{
+		{
+			name: "from json",
+			json: `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"seller":{"prefecture":"東京都"}}}}}}}}`,
+			body: `<table><tr><th>発送元の地域</th><td>大阪府</td></tr></table>`,
+			want: "東京都",
+		},
+		{
+			name: "from table",
+			json: `{}`,
+			body: `<table><tr><th>発送元の地域</th><td> 大阪府 </td></tr></table>`,
+			want: "大阪府",
+		},
+		{
+			name: "from definition list",
+			json: `{}`,
+			body: `<dl><dt>発送元の地域</dt><dd>北海道</dd></dl>`,
+			want: "北海道",
+		},
+		{
+			name: "not present",
+			json: `{}`,
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Seller == nil {
+				t.Fatalf("Seller is nil")
+			}
+			if got.Seller.Location != tc.want {
+				t.Fatalf("Seller.Location got %q, want %q", got.Seller.Location, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_shippingDays(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want string
+	}{
+		{
+			name: "from json",
+			json: `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"shipSchedule":"1～2日で発送"}}}}}}}`,
+			body: `<table><tr><th>発送までの日数</th><td>3～7日で発送</td></tr></table>`,
+			want: "1～2日で発送",
+		},
+		{
+			name: "from table",
+			json: `{}`,
+			body: `<table><tr><th>発送までの日数</th><td>3～7日で発送</td></tr></table>`,
+			want: "3～7日で発送",
+		},
+		{
+			name: "not present",
+			json: `{}`,
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ShippingDays != tc.want {
+				t.Fatalf("ShippingDays got %q, want %q", got.ShippingDays, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_coupon(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		json         string
+		wantHas      bool
+		wantDesc     string
+		wantFeatured bool
+	}{
+		{
+			name:     "multiple coupons",
+			json:     `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"promotion":{"coupons":[{"title":"10%OFF"},{"title":" 送料無料 "}]}}}}}}}}`,
+			wantHas:  true,
+			wantDesc: "10%OFF / 送料無料",
+		},
+		{
+			name:         "featured",
+			json:         `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"promotion":{"isFeatured":true}}}}}}}}`,
+			wantFeatured: true,
+		},
+		{
+			name:    "no promotion",
+			json:    `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"t"}}}}}}}`,
+			wantHas: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			if err := json.Unmarshal([]byte(tc.json), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got := s.extractItemFromJSON(&data, "x1234567890")
+			if got.HasCoupon != tc.wantHas {
+				t.Fatalf("HasCoupon got %v, want %v", got.HasCoupon, tc.wantHas)
+			}
+			if got.CouponDescription != tc.wantDesc {
+				t.Fatalf("CouponDescription got %q, want %q", got.CouponDescription, tc.wantDesc)
+			}
+			if got.IsFeatured != tc.wantFeatured {
+				t.Fatalf("IsFeatured got %v, want %v", got.IsFeatured, tc.wantFeatured)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_bidRestriction(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		want model.BidRestriction
+	}{
+		{name: "none", json: `{"title":"t"}`, want: model.BidRestrictionNone},
+		{name: "verified only", json: `{"bidderRestriction":{"isVerifiedOnly":true}}`, want: model.BidRestrictionVerifiedOnly},
+		{name: "premium only", json: `{"bidderRestriction":{"isPremiumOnly":true}}`, want: model.BidRestrictionPremiumOnly},
+		{name: "both prefers premium", json: `{"bidderRestriction":{"isPremiumOnly":true,"isVerifiedOnly":true}}`, want: model.BidRestrictionPremiumOnly},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			raw := `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` + tc.json + `}}}}}}`
+			if err := json.Unmarshal([]byte(raw), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			if got := s.extractItemFromJSON(&data, "x1234567890").BidRestriction; got != tc.want {
+				t.Errorf("BidRestriction got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_immediateBuyAvailable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		want bool
+	}{
+		{name: "no immediate price", json: `{"status":"open"}`, want: false},
+		{name: "immediate price without bids", json: `{"status":"open","bidorbuy":5000}`, want: true},
+		{name: "taxin immediate price without bids", json: `{"status":"open","taxinBidorbuy":5500}`, want: true},
+		{name: "immediate price with bids", json: `{"status":"open","bidorbuy":5000,"bids":2}`, want: false},
+		{name: "explicit flag with bids", json: `{"status":"open","bidorbuy":5000,"bids":2,"isBidorbuyAvailable":true}`, want: true},
+		{name: "explicit flag disabled", json: `{"status":"open","bidorbuy":5000,"isBidorbuyAvailable":false}`, want: false},
+		{name: "finished", json: `{"status":"closed","bidorbuy":5000,"isBidorbuyAvailable":true}`, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			raw := `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` + tc.json + `}}}}}}`
+			if err := json.Unmarshal([]byte(raw), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			if got := s.extractItemFromJSON(&data, "x1234567890").ImmediateBuyAvailable; got != tc.want {
+				t.Errorf("ImmediateBuyAvailable got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_tags(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{name: "tags", json: `{"tags":[" カメラ ","Nikon","","カメラ"]}`, want: []string{"カメラ", "Nikon"}},
+		{name: "no tags", json: `{"title":"t"}`, want: []string{}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			raw := `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` + tc.json + `}}}}}}`
+			if err := json.Unmarshal([]byte(raw), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got := s.extractItemFromJSON(&data, "x1234567890").Tags
+			if got == nil || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Tags got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_variations(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		want []*model.Variation
+	}{
+		{
+			name: "store listing with variations",
+			json: `{"variations":[` +
+				`{"name":"ブラック / M","price":2000,"taxinPrice":2200,"stock":3},` +
+				`{"name":" ホワイト / L ","price":2500,"stock":0},` +
+				`{"name":"","price":100,"stock":1}]}`,
+			want: []*model.Variation{
+				{Name: "ブラック / M", Price: 2200, Stock: 3},
+				{Name: "ホワイト / L", Price: 2500, Stock: 0},
+			},
+		},
+		{name: "no variations", json: `{"title":"t"}`, want: []*model.Variation{}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			raw := `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":` + tc.json + `}}}}}}`
+			if err := json.Unmarshal([]byte(raw), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got := s.extractItemFromJSON(&data, "x1234567890").Variations
+			if got == nil || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Variations got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRatingPercentage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		good, bad int64
+		want      float64
+	}{
+		{name: "no ratings", good: 0, bad: 0, want: 0},
+		{name: "all good", good: 10, bad: 0, want: 100},
+		{name: "all bad", good: 0, bad: 5, want: 0},
+		{name: "mixed", good: 3, bad: 1, want: 75},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ratingPercentage(tc.good, tc.bad); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_prefersJSONBidCountOnMismatch(t *testing.T) {
+	t.Parallel()
+
+	s := &yahooScraper{}
+	html := `<html><head></head><body>
+		<script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"bids":12}}}}}}}</script>
+		<p>このオークションには 10件の入札があります</p>
+	</body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	textCount, ok := bidCountFromText(doc)
+	if !ok || textCount != 10 {
+		t.Fatalf("bidCountFromText got (%d, %v), want (10, true)", textCount, ok)
+	}
+
+	got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.BidCount != 12 {
+		t.Fatalf("BidCount got %d, want %d", got.BidCount, 12)
+	}
+}
+
+func TestBidCountFromText_missing(t *testing.T) {
+	t.Parallel()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body><p>入札はまだありません</p></body></html>`))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	if _, ok := bidCountFromText(doc); ok {
+		t.Fatalf("expected no bid count")
+	}
+}
+
 func TestYahooScraper_extractItemFromJSON_statusMapping(t *testing.T) {
 	t.Parallel()
 
@@ -214,3 +785,477 @@ func TestYahooScraper_extractItemFromJSON_timeParseFailureLeavesZero(t *testing.
 		t.Fatalf("EndTime got %v, want zero", got.AuctionInfo.EndTime)
 	}
 }
+
+func TestNewYahooScraper_withBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">` +
+			`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title","price":100,"status":"open"}}}}}}}` +
+			`</script></head><body></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	item, err := repo.FetchByID(context.Background(), "x1234567890")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/jp/auction/x1234567890" {
+		t.Errorf("path got %q, want %q", gotPath, "/jp/auction/x1234567890")
+	}
+	if item.Title != "title" || item.CurrentPrice != 100 || item.Status != model.StatusActive {
+		t.Errorf("item got %+v", item)
+	}
+}
+
+func TestYahooScraper_FetchByID_skipImages(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><meta property="og:image" content="https://example.com/og.jpg"><script id="__NEXT_DATA__">` +
+			`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title","img":[{"image":"https://example.com/1.jpg","isMain":true},{"image":"https://example.com/2.jpg"}]}}}}}}}` +
+			`</script></head><body></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()), WithSkipImages())
+	item, err := repo.FetchByID(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(item.Images) != 0 {
+		t.Errorf("Images got %v, want empty", item.Images)
+	}
+	if item.Thumbnail != "https://example.com/1.jpg" {
+		t.Errorf("Thumbnail got %q, want %q", item.Thumbnail, "https://example.com/1.jpg")
+	}
+	if item.Title != "title" {
+		t.Errorf("Title got %q, want %q", item.Title, "title")
+	}
+}
+
+func TestYahooScraper_FetchStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		status string
+		want   model.Status
+	}{
+		{name: "open", status: "open", want: model.StatusActive},
+		{name: "closed", status: "closed", want: model.StatusFinished},
+		{name: "canceled", status: "cancel", want: model.StatusCanceled},
+		{name: "unknown", status: "", want: model.StatusUnspecified},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"status":"` + tc.status + `"}}}}}}}</script></head></html>`))
+			}))
+			defer srv.Close()
+
+			repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+			got, err := repo.FetchStatus(context.Background(), "x1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_imageFallback(t *testing.T) {
+	t.Parallel()
+
+	const og = `<meta property="og:image" content="https://auctions.c.yimg.jp/images.auctions.yahoo.co.jp/image/thumb.jpg">`
+
+	cases := []struct {
+		name   string
+		json   string
+		head   string
+		fields model.ItemFields
+		want   []string
+	}{
+		{
+			name:   "gallery present",
+			json:   `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"img":[{"image":"https://example.com/1.jpg"}]}}}}}}}`,
+			head:   og,
+			fields: model.ItemFieldImages,
+			want:   []string{"https://example.com/1.jpg"},
+		},
+		{
+			name:   "only thumbnail",
+			json:   `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"img":[{"image":""}]}}}}}}}`,
+			head:   og,
+			fields: model.ItemFieldImages,
+			want:   []string{"https://auctions.c.yimg.jp/images.auctions.yahoo.co.jp/image/thumb.jpg"},
+		},
+		{
+			name:   "no images at all",
+			json:   `{}`,
+			fields: model.ItemFieldImages,
+			want:   []string{},
+		},
+		{
+			name:   "images not requested",
+			json:   `{}`,
+			head:   og,
+			fields: model.ItemFieldsNone,
+			want:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head>` + tc.head + `<script id="__NEXT_DATA__">` + tc.json + `</script></head><body></body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", tc.fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got.Images == nil) != (tc.want == nil) || strings.Join(got.Images, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("Images got %#v, want %#v", got.Images, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_thumbnail(t *testing.T) {
+	t.Parallel()
+
+	const og = `<meta property="og:image" content="https://example.com/og.jpg">`
+
+	cases := []struct {
+		name   string
+		img    string
+		head   string
+		fields model.ItemFields
+		want   string
+	}{
+		{
+			name:   "main image marked",
+			img:    `[{"image":"https://example.com/1.jpg"},{"image":"https://example.com/2.jpg","isMain":true}]`,
+			fields: model.ItemFieldImages,
+			want:   "https://example.com/2.jpg",
+		},
+		{
+			name:   "defaults to first image",
+			img:    `[{"image":""},{"image":"https://example.com/1.jpg"},{"image":"https://example.com/2.jpg"}]`,
+			fields: model.ItemFieldImages,
+			want:   "https://example.com/1.jpg",
+		},
+		{
+			name:   "set even when images are not requested",
+			img:    `[{"image":"https://example.com/1.jpg"}]`,
+			fields: model.ItemFieldsNone,
+			want:   "https://example.com/1.jpg",
+		},
+		{
+			name:   "falls back to og image",
+			img:    `[]`,
+			head:   og,
+			fields: model.ItemFieldImages,
+			want:   "https://example.com/og.jpg",
+		},
+		{
+			name:   "no images",
+			img:    `[]`,
+			fields: model.ItemFieldImages,
+			want:   "",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head>` + tc.head + `<script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"img":` +
+				tc.img + `}}}}}}}</script></head><body></body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", tc.fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Thumbnail != tc.want {
+				t.Errorf("Thumbnail got %q, want %q", got.Thumbnail, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_condition(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		json string
+		body string
+		want model.Condition
+	}{
+		{
+			name: "from json",
+			json: `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"conditionName":"目立った傷や汚れなし"}}}}}}}`,
+			body: `<table><tr><th>商品の状態</th><td>傷や汚れあり</td></tr></table>`,
+			want: model.ConditionNoVisibleDamage,
+		},
+		{
+			name: "from table",
+			json: `{}`,
+			body: `<table><tr><th>商品の状態</th><td>傷や汚れあり</td></tr></table>`,
+			want: model.ConditionDamaged,
+		},
+		{
+			name: "not present",
+			json: `{}`,
+			want: model.ConditionUnspecified,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head><body>` + tc.body + `</body></html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsNone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Condition != tc.want {
+				t.Fatalf("Condition got %v, want %v", got.Condition, tc.want)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemFromJSON_finalPrice(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		status    string
+		wantFinal int64
+	}{
+		{name: "closed", status: "closed", wantFinal: 5500},
+		{name: "open", status: "open", wantFinal: 0},
+		{name: "canceled", status: "cancel", wantFinal: 0},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var data NextData
+			raw := `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"status":"` + tc.status + `","price":5000,"taxinPrice":5500}}}}}}}`
+			if err := json.Unmarshal([]byte(raw), &data); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got := s.extractItemFromJSON(&data, "x1234567890")
+			if got.CurrentPrice != 5500 {
+				t.Errorf("CurrentPrice got %d, want 5500", got.CurrentPrice)
+			}
+			if got.FinalPrice != tc.wantFinal {
+				t.Errorf("FinalPrice got %d, want %d", got.FinalPrice, tc.wantFinal)
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_endTimeFromRelativeTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 29, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	html := `<html><head><script id="__NEXT_DATA__">` +
+		`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"title":"title"}}}}}}}` +
+		`</script></head><body><dl><dt>残り時間</dt><dd>残り 3時間</dd></dl></body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to build doc: %v", err)
+	}
+
+	s := &yahooScraper{now: func() time.Time { return now }}
+	got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldsAll)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := now.Add(3 * time.Hour); !got.AuctionInfo.EndTime.Equal(want) {
+		t.Fatalf("AuctionInfo.EndTime got %v, want %v", got.AuctionInfo.EndTime, want)
+	}
+}
+
+func TestYahooScraper_extractItemInfo_questions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want []*model.QA
+	}{
+		{
+			name: "from json",
+			html: `<html><head><script id="__NEXT_DATA__">` +
+				`{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"questions":[` +
+				`{"question":"動作しますか？","questionTime":"2025-12-28T10:00:00+09:00","answer":"はい","answerTime":"2025-12-28T11:00:00+09:00"},` +
+				`{"question":"箱はありますか？","questionTime":"2025-12-29T10:00:00+09:00"}]}}}}}}}` +
+				`</script></head><body></body></html>`,
+			want: []*model.QA{
+				{
+					Question:   "動作しますか？",
+					Answer:     "はい",
+					AskedAt:    time.Date(2025, 12, 28, 10, 0, 0, 0, time.FixedZone("", 9*60*60)),
+					AnsweredAt: time.Date(2025, 12, 28, 11, 0, 0, 0, time.FixedZone("", 9*60*60)),
+				},
+				{
+					Question: "箱はありますか？",
+					AskedAt:  time.Date(2025, 12, 29, 10, 0, 0, 0, time.FixedZone("", 9*60*60)),
+				},
+			},
+		},
+		{
+			name: "from html",
+			html: `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{}}}}}}}</script></head>` +
+				`<body><section id="qanda"><ul><li><p class="Question">動作しますか？</p><p class="Answer">はい</p></li></ul></section></body></html>`,
+			want: []*model.QA{{Question: "動作しますか？", Answer: "はい"}},
+		},
+		{
+			name: "no questions",
+			html: `<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{}}}}}}}</script></head><body></body></html>`,
+			want: []*model.QA{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldQuestions)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Questions == nil || len(got.Questions) != len(tc.want) {
+				t.Fatalf("Questions got %#v, want %d entries", got.Questions, len(tc.want))
+			}
+			for i, want := range tc.want {
+				q := got.Questions[i]
+				if q.Question != want.Question || q.Answer != want.Answer || !q.AskedAt.Equal(want.AskedAt) || !q.AnsweredAt.Equal(want.AnsweredAt) {
+					t.Errorf("Questions[%d] got %+v, want %+v", i, q, want)
+				}
+			}
+		})
+	}
+}
+
+func TestYahooScraper_extractItemInfo_description(t *testing.T) {
+	t.Parallel()
+
+	truncatedBody := `<body><div class="ProductExplanation"><div class="ProductExplanation__commentBody"><p>冒頭部分</p></div>` +
+		`<a class="ProductExplanation__more">続きを見る</a></div></body>`
+
+	cases := []struct {
+		name     string
+		json     string
+		wantDesc string
+		wantText string
+	}{
+		{
+			name:     "json is preferred over truncated html",
+			json:     `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"descriptionHtml":"<p>冒頭部分</p><p>続きの全文</p>"}}}}}}}`,
+			wantDesc: "<p>冒頭部分</p><p>続きの全文</p>",
+			wantText: "冒頭部分続きの全文",
+		},
+		{
+			name:     "html fallback when json has no description",
+			json:     `{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{}}}}}}}`,
+			wantDesc: "<p>冒頭部分</p>",
+			wantText: "冒頭部分",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			html := `<html><head><script id="__NEXT_DATA__">` + tc.json + `</script></head>` + truncatedBody + `</html>`
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			s := &yahooScraper{}
+			got, err := s.extractItemInfo(context.Background(), doc, "x1234567890", model.ItemFieldDescription)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Description != tc.wantDesc {
+				t.Errorf("Description got %q, want %q", got.Description, tc.wantDesc)
+			}
+			if got.DescriptionText != tc.wantText {
+				t.Errorf("DescriptionText got %q, want %q", got.DescriptionText, tc.wantText)
+			}
+		})
+	}
+}
+
+func TestDescriptionFromHTML_detectsTruncation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		body          string
+		wantTruncated bool
+	}{
+		{name: "truncated", body: `<div><div class="ProductExplanation__commentBody">a</div><a>続きを見る</a></div>`, wantTruncated: true},
+		{name: "full", body: `<div><div class="ProductExplanation__commentBody">a</div></div>`, wantTruncated: false},
+	}
+
+	for _, tc := range cases {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body>` + tc.body + `</body></html>`))
+		if err != nil {
+			t.Fatalf("failed to build doc: %v", err)
+		}
+		if _, got := descriptionFromHTML(doc); got != tc.wantTruncated {
+			t.Errorf("%s: truncated got %v, want %v", tc.name, got, tc.wantTruncated)
+		}
+	}
+}
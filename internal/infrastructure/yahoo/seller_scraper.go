@@ -0,0 +1,150 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/trace"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+type yahooSellerScraper struct {
+	client  *http.Client
+	baseURL string
+	tracer  trace.Tracer
+	retry   retryPolicy
+
+	validators *validatorCache // nil の場合は条件付きリクエストを送信しない
+	solver     ChallengeSolver // nil の場合は確認ページを通過せずにエラーとする
+
+	stats *requestStats // リクエスト数と最後に成功した日時
+}
+
+// NewYahooSellerScraper は新しいSellerRepositoryの実装を作成します
+// opts でベースURLや http.Client を変更できます
+func NewYahooSellerScraper(opts ...Option) repository.SellerRepository {
+	return newYahooSellerScraper(newOptions(defaultCategoryBaseURL, opts))
+}
+
+// newYahooSellerScraper はテスト容易性のための内部コンストラクタです。
+func newYahooSellerScraper(o *options) repository.SellerRepository {
+	return &yahooSellerScraper{
+		client:  o.client,
+		baseURL: o.baseURL,
+		tracer:  o.tracer,
+		retry:   o.retry,
+
+		validators: o.validators,
+		solver:     o.solver,
+
+		stats: &requestStats{},
+	}
+}
+
+// Stats はこのスクレイパーのリクエスト数と最後に成功した日時を返します
+func (s *yahooSellerScraper) Stats() Stats {
+	return s.stats.snapshot()
+}
+
+// FetchSellerRating は出品者の評価ページから評価の内訳を取得します
+func (s *yahooSellerScraper) FetchSellerRating(ctx context.Context, sellerID string) (rating *model.SellerRating, err error) {
+	// 例: https://auctions.yahoo.co.jp/jp/show/rating?userID={sellerID}
+	targetURL := fmt.Sprintf("%s/jp/show/rating?userID=%s", s.baseURL, url.QueryEscape(sellerID))
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchSellerRating", attrSellerID.String(sellerID), attrURL.String(targetURL))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	doc, err := fetchHTML(ctx, s.client, targetURL, s.retry, s.validators, s.solver)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractSellerRating(doc, sellerID), nil
+}
+
+// FetchSellerItemCount は出品者の出品一覧ページから出品中の商品の総数のみを取得します
+// 出品中の商品がない場合、総数の要素が表示されないため0を返します
+func (s *yahooSellerScraper) FetchSellerItemCount(ctx context.Context, sellerID string) (count int64, err error) {
+	// 例: https://auctions.yahoo.co.jp/seller/{sellerID}
+	targetURL := fmt.Sprintf("%s/seller/%s", s.baseURL, url.PathEscape(sellerID))
+
+	ctx, span := startSpan(ctx, s.tracer, "yahoo.FetchSellerItemCount", attrSellerID.String(sellerID), attrURL.String(targetURL))
+	defer func() {
+		s.stats.record(err, time.Now())
+		endSpan(span, err)
+	}()
+
+	doc, err := fetchHTML(ctx, s.client, targetURL, s.retry, s.validators, s.solver)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTotalCount(doc), nil
+}
+
+// ratingLabels は評価ページの行見出しと評価区分の対応です
+// 「良い」と「非常に良い」のように部分一致すると誤るため、完全一致で判定します
+var ratingLabels = map[string]string{
+	"非常に良い":   "good",
+	"良い":      "good",
+	"どちらでもない": "normal",
+	"悪い":      "bad",
+	"非常に悪い":   "bad",
+}
+
+// extractSellerRating は評価ページのHTMLから評価の内訳を抽出します
+// 評価表は「過去6ヶ月」「過去12ヶ月」「全期間」の列を持ち、行が評価区分です
+// 評価表が見つからない（評価がない）場合は、すべて0の SellerRating を返します
+func extractSellerRating(doc *goquery.Document, sellerID string) *model.SellerRating {
+	rating := &model.SellerRating{SellerID: sellerID}
+
+	doc.Find("table").EachWithBreak(func(_ int, table *goquery.Selection) bool {
+		// 見出し行から期間ごとの列位置を特定する
+		columns := make(map[int]*model.RatingCounts)
+		table.Find("tr").First().Children().Each(func(i int, cell *goquery.Selection) {
+			text := strings.TrimSpace(cell.Text())
+			switch {
+			case strings.Contains(text, "12ヶ月"):
+				columns[i] = &rating.Last12Months
+			case strings.Contains(text, "6ヶ月"):
+				columns[i] = &rating.Last6Months
+			case strings.Contains(text, "全期間"):
+				columns[i] = &rating.Total
+			}
+		})
+		if len(columns) == 0 {
+			return true
+		}
+
+		table.Find("tr").Slice(1, goquery.ToEnd).Each(func(_ int, row *goquery.Selection) {
+			cells := row.Children()
+			kind, ok := ratingLabels[strings.TrimSpace(cells.First().Text())]
+			if !ok {
+				return
+			}
+			for i, counts := range columns {
+				n := parseCount(cells.Eq(i).Text())
+				switch kind {
+				case "good":
+					counts.Good += n
+				case "normal":
+					counts.Normal += n
+				case "bad":
+					counts.Bad += n
+				}
+			}
+		})
+		return false
+	})
+
+	return rating
+}
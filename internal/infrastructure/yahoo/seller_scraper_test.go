@@ -0,0 +1,145 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestExtractSellerRating(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want model.SellerRating
+	}{
+		{
+			name: "rating table",
+			html: `<html><body>
+				<table><tr><th>ID</th><td>seller1</td></tr></table>
+				<table>
+					<tr><th></th><th>過去1ヶ月</th><th>過去6ヶ月</th><th>過去12ヶ月</th><th>全期間</th></tr>
+					<tr><th>非常に良い</th><td>1</td><td>10</td><td>20</td><td>1,000</td></tr>
+					<tr><th>良い</th><td>0</td><td>2</td><td>3</td><td>50</td></tr>
+					<tr><th>どちらでもない</th><td>0</td><td>1</td><td>1</td><td>5</td></tr>
+					<tr><th>悪い</th><td>0</td><td>0</td><td>1</td><td>2</td></tr>
+					<tr><th>非常に悪い</th><td>0</td><td>1</td><td>1</td><td>3</td></tr>
+				</table>
+			</body></html>`,
+			want: model.SellerRating{
+				SellerID:     "seller1",
+				Last6Months:  model.RatingCounts{Good: 12, Normal: 1, Bad: 1},
+				Last12Months: model.RatingCounts{Good: 23, Normal: 1, Bad: 2},
+				Total:        model.RatingCounts{Good: 1050, Normal: 5, Bad: 5},
+			},
+		},
+		{
+			name: "no ratings",
+			html: `<html><body><p>まだ評価はありません</p></body></html>`,
+			want: model.SellerRating{SellerID: "seller1"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("failed to build doc: %v", err)
+			}
+
+			got := extractSellerRating(doc, "seller1")
+			if *got != tc.want {
+				t.Fatalf("got %+v, want %+v", *got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewYahooSellerScraper_withBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotUserID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserID = r.URL.Query().Get("userID")
+		_, _ = w.Write([]byte(`<html><body><table>
+			<tr><th></th><th>過去6ヶ月</th></tr>
+			<tr><th>良い</th><td>4</td></tr>
+		</table></body></html>`))
+	}))
+	defer srv.Close()
+
+	repo := NewYahooSellerScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	got, err := repo.FetchSellerRating(context.Background(), "seller 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/jp/show/rating" {
+		t.Errorf("path got %q, want %q", gotPath, "/jp/show/rating")
+	}
+	if gotUserID != "seller 1" {
+		t.Errorf("userID got %q, want %q", gotUserID, "seller 1")
+	}
+	if got.Last6Months.Good != 4 {
+		t.Errorf("Last6Months.Good got %d, want 4", got.Last6Months.Good)
+	}
+}
+
+func TestYahooSellerScraper_FetchSellerItemCount(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		html string
+		want int64
+	}{
+		{
+			name: "listings",
+			html: `<html><body><div class="Result__header"><div class="SearchMode"><div class="Tab"><ul>
+				<li class="Tab__item Tab__item--current"><div><span class="Tab__subText">1,234件</span></div></li>
+			</ul></div></div></div></body></html>`,
+			want: 1234,
+		},
+		{
+			name: "no listings",
+			html: `<html><body><p>現在出品中の商品はありません</p></body></html>`,
+			want: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_, _ = w.Write([]byte(tc.html))
+			}))
+			defer srv.Close()
+
+			repo := NewYahooSellerScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+			got, err := repo.FetchSellerItemCount(context.Background(), "seller1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotPath != "/seller/seller1" {
+				t.Errorf("path got %q, want %q", gotPath, "/seller/seller1")
+			}
+			if got != tc.want {
+				t.Errorf("count got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package yahoo
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// shippingSizePattern は荷物のサイズの表記（例: "80サイズ"、"60 size"）にマッチします
+var shippingSizePattern = regexp.MustCompile(`(?i)([0-9]+)\s*(?:サイズ|size)`)
+
+// shippingWeightPattern は荷物の重量の表記（例: "2kg"、"1.5 ｋｇ"、"500g"）にマッチします
+var shippingWeightPattern = regexp.MustCompile(`(?i)([0-9]+(?:\.[0-9]+)?)\s*(kg|g|キロ|グラム)`)
+
+// parseShippingSize は荷物のサイズの表記を "80サイズ" の形に揃えます
+// 数値を含まない表記（例: "宅急便コンパクト"）はそのまま返します。空の場合は空文字を返します
+func parseShippingSize(text string) string {
+	text = strings.TrimSpace(normalizeText(text))
+	if m := shippingSizePattern.FindStringSubmatch(text); m != nil {
+		return m[1] + "サイズ"
+	}
+	return text
+}
+
+// parseShippingWeight は荷物の重量の表記をグラム単位の値に変換します
+// 重量を読み取れない場合は0を返します
+func parseShippingWeight(text string) int64 {
+	m := shippingWeightPattern.FindStringSubmatch(normalizeText(text))
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(m[2]) {
+	case "kg", "キロ":
+		v *= 1000
+	}
+	return int64(math.Round(v))
+}
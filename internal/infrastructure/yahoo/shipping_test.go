@@ -0,0 +1,46 @@
+package yahoo
+
+import "testing"
+
+func TestParseShippingSize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "80サイズ", want: "80サイズ"},
+		{in: " ６０ サイズ ", want: "60サイズ"},
+		{in: "120 size", want: "120サイズ"},
+		{in: "宅急便コンパクト", want: "宅急便コンパクト"},
+		{in: "", want: ""},
+	}
+
+	for _, tc := range cases {
+		if got := parseShippingSize(tc.in); got != tc.want {
+			t.Errorf("parseShippingSize(%q) got %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseShippingWeight(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{in: "2kg", want: 2000},
+		{in: "1.5 ｋｇ", want: 1500},
+		{in: "500g", want: 500},
+		{in: "約3キロ", want: 3000},
+		{in: "軽量", want: 0},
+		{in: "", want: 0},
+	}
+
+	for _, tc := range cases {
+		if got := parseShippingWeight(tc.in); got != tc.want {
+			t.Errorf("parseShippingWeight(%q) got %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
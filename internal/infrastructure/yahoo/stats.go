@@ -0,0 +1,60 @@
+package yahoo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats はスクレイパーのリクエスト数と最後に成功した日時の集計値です
+// Prometheus などを導入せずに簡易的な死活監視を行うために利用します
+type Stats struct {
+	Requests    int64     // 送信したリクエストの総数
+	Successes   int64     // 取得と抽出に成功した回数
+	Failures    int64     // 取得または抽出に失敗した回数
+	LastSuccess time.Time // 最後に成功した日時。一度も成功していない場合はゼロ値
+}
+
+// StatsProvider は Stats を提供するスクレイパーが実装するインターフェースです
+// NewYahooScraper などはリポジトリのインターフェースを返すため、型アサーションで取得します
+type StatsProvider interface {
+	Stats() Stats
+}
+
+// requestStats は並行に呼び出されても安全な Stats の集計器です
+// nil の場合は何も記録しません
+type requestStats struct {
+	requests    atomic.Int64
+	successes   atomic.Int64
+	failures    atomic.Int64
+	lastSuccess atomic.Int64 // UnixNano。0 の場合は一度も成功していない
+}
+
+// record はリクエスト1回分の結果を記録します
+func (s *requestStats) record(err error, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.requests.Add(1)
+	if err != nil {
+		s.failures.Add(1)
+		return
+	}
+	s.successes.Add(1)
+	s.lastSuccess.Store(now.UnixNano())
+}
+
+// snapshot は現在の集計値を返します
+func (s *requestStats) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	stats := Stats{
+		Requests:  s.requests.Load(),
+		Successes: s.successes.Load(),
+		Failures:  s.failures.Load(),
+	}
+	if last := s.lastSuccess.Load(); last != 0 {
+		stats.LastSuccess = time.Unix(0, last)
+	}
+	return stats
+}
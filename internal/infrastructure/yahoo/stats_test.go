@@ -0,0 +1,88 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestStats_concurrent(t *testing.T) {
+	t.Parallel()
+
+	stats := &requestStats{}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%4 == 0 {
+				err = errors.New("boom")
+			}
+			stats.record(err, now)
+		}(i)
+	}
+	wg.Wait()
+
+	got := stats.snapshot()
+	want := Stats{Requests: 100, Successes: 75, Failures: 25, LastSuccess: now}
+	if got.Requests != want.Requests || got.Successes != want.Successes || got.Failures != want.Failures {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if !got.LastSuccess.Equal(want.LastSuccess) {
+		t.Errorf("LastSuccess got %v, want %v", got.LastSuccess, want.LastSuccess)
+	}
+}
+
+func TestRequestStats_nil(t *testing.T) {
+	t.Parallel()
+
+	var stats *requestStats
+	stats.record(nil, time.Now())
+	if got := stats.snapshot(); got != (Stats{}) {
+		t.Errorf("got %+v, want zero value", got)
+	}
+}
+
+func TestYahooScraper_Stats(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/jp/auction/ok" {
+			_, _ = w.Write([]byte(`<html><head><script id="__NEXT_DATA__">{"props":{"pageProps":{"initialState":{"item":{"detail":{"item":{"auctionId":"ok","title":"t"}}}}}}}</script></head></html>`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	repo := newYahooScraper(newOptions(srv.URL, []Option{WithHTTPClient(srv.Client())}))
+	provider, ok := repo.(StatsProvider)
+	if !ok {
+		t.Fatal("scraper does not implement StatsProvider")
+	}
+	if got := provider.Stats(); got != (Stats{}) {
+		t.Fatalf("initial stats got %+v, want zero value", got)
+	}
+
+	if _, err := repo.FetchByID(context.Background(), "ok"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.FetchByID(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error for missing auction")
+	}
+
+	got := provider.Stats()
+	if got.Requests != 2 || got.Successes != 1 || got.Failures != 1 {
+		t.Errorf("got %+v, want 2 requests, 1 success, 1 failure", got)
+	}
+	if got.LastSuccess.IsZero() {
+		t.Error("LastSuccess should be set after a successful fetch")
+	}
+}
@@ -0,0 +1,45 @@
+package yahoo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName は OpenTelemetry の計装スコープ名です
+const tracerName = "jo3qma.com/yahoo_auctions/internal/infrastructure/yahoo"
+
+// スパンに付与する属性キー
+const (
+	attrAuctionID  = attribute.Key("yahoo.auction_id")
+	attrCategoryID = attribute.Key("yahoo.category_id")
+	attrSellerID   = attribute.Key("yahoo.seller_id")
+	attrURL        = attribute.Key("url.full")
+	attrStatusCode = attribute.Key("http.response.status_code")
+)
+
+// defaultTracer はトレーサーが設定されていない場合に利用する no-op のトレーサーです
+func defaultTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(tracerName)
+}
+
+// startSpan はスクレイピング処理のスパンを開始します
+// tracer が nil の場合は no-op のトレーサーを利用します
+func startSpan(ctx context.Context, tracer trace.Tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if tracer == nil {
+		tracer = defaultTracer()
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan はエラーがあればスパンに記録してから終了します
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,91 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingSpan は設定された属性とステータスを記録するテスト用スパンです
+type recordingSpan struct {
+	noop.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordingSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+// recordingTracerProvider は開始されたスパンを記録するテスト用の TracerProvider です
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	spans []*recordingSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordingSpan{name: name, attrs: make(map[attribute.Key]attribute.Value)}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	t.provider.spans = append(t.provider.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func TestYahooScraper_FetchByID_recordsSpan(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	tp := &recordingTracerProvider{}
+	repo := NewYahooScraper(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()), WithTracerProvider(tp))
+
+	if _, err := repo.FetchByID(context.Background(), "x1234567890"); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if len(tp.spans) != 1 {
+		t.Fatalf("spans len got %d, want 1", len(tp.spans))
+	}
+	span := tp.spans[0]
+	if span.name != "yahoo.FetchByID" {
+		t.Errorf("name got %q, want %q", span.name, "yahoo.FetchByID")
+	}
+	if got := span.attrs[attrAuctionID].AsString(); got != "x1234567890" {
+		t.Errorf("auction_id got %q, want %q", got, "x1234567890")
+	}
+	if got := span.attrs[attrStatusCode].AsInt64(); got != http.StatusNotFound {
+		t.Errorf("status_code got %d, want %d", got, http.StatusNotFound)
+	}
+	if span.status != codes.Error {
+		t.Errorf("status got %v, want %v", span.status, codes.Error)
+	}
+	if !span.ended {
+		t.Errorf("span was not ended")
+	}
+}
@@ -0,0 +1,90 @@
+package yahoo
+
+import (
+	"net/http"
+	"sync"
+)
+
+// maxValidatorEntries はバリデーターキャッシュに保持するURLの最大数です
+const maxValidatorEntries = 1000
+
+// validatorEntry はURLごとに保持する検証子と本文です
+type validatorEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// validatorCache は ETag / Last-Modified を用いた条件付きリクエストのためのキャッシュです
+// 304 Not Modified の場合は、前回取得した本文を再利用します。複数のgoroutineから安全に利用できます
+type validatorCache struct {
+	mu      sync.Mutex
+	entries map[string]*validatorEntry
+}
+
+// newValidatorCache は空のバリデーターキャッシュを作成します
+func newValidatorCache() *validatorCache {
+	return &validatorCache{
+		entries: make(map[string]*validatorEntry),
+	}
+}
+
+// setConditionalHeaders は url について保持している検証子をリクエストヘッダーに設定します
+// c が nil の場合は何もしません
+func (c *validatorCache) setConditionalHeaders(req *http.Request, url string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[url]
+	if !ok {
+		return
+	}
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+// cachedBody は url について保持している本文を返します
+func (c *validatorCache) cachedBody(url string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	return e.body, true
+}
+
+// store はレスポンスの検証子と本文を保持します
+// 検証子（ETag / Last-Modified）がないレスポンスは条件付きリクエストに使えないため保持しません
+func (c *validatorCache) store(url string, header http.Header, body []byte) {
+	if c == nil {
+		return
+	}
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxValidatorEntries {
+		// 上限に達した場合は任意の1件を破棄する
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[url] = &validatorEntry{etag: etag, lastModified: lastModified, body: body}
+}
@@ -0,0 +1,90 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchHTML_conditionalRequest(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		header     string
+		value      string
+		wantHeader string
+	}{
+		{name: "etag", header: "ETag", value: `"v1"`, wantHeader: "If-None-Match"},
+		{name: "last-modified", header: "Last-Modified", value: "Fri, 02 Jan 2026 03:04:05 GMT", wantHeader: "If-Modified-Since"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if r.Header.Get(tc.wantHeader) == tc.value {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set(tc.header, tc.value)
+				_, _ = w.Write([]byte(`<html><body><p>cached</p></body></html>`))
+			}))
+			defer srv.Close()
+
+			cache := newValidatorCache()
+			for i := 0; i < 2; i++ {
+				doc, err := fetchHTML(context.Background(), srv.Client(), srv.URL, retryPolicy{}, cache, nil)
+				if err != nil {
+					t.Fatalf("request %d: unexpected error: %v", i, err)
+				}
+				if got := strings.TrimSpace(doc.Find("p").Text()); got != "cached" {
+					t.Fatalf("request %d: body got %q, want %q", i, got, "cached")
+				}
+			}
+			if got := calls.Load(); got != 2 {
+				t.Fatalf("calls got %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestFetchHTML_withoutValidators(t *testing.T) {
+	t.Parallel()
+
+	var gotHeader atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			gotHeader.Store(true)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`<html></html>`))
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetchHTML(context.Background(), srv.Client(), srv.URL, retryPolicy{}, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if gotHeader.Load() {
+		t.Fatalf("conditional header was sent without validator cache")
+	}
+}
+
+func TestValidatorCache_storeSkipsResponsesWithoutValidators(t *testing.T) {
+	t.Parallel()
+
+	c := newValidatorCache()
+	c.store("http://example.com", http.Header{}, []byte("body"))
+	if _, ok := c.cachedBody("http://example.com"); ok {
+		t.Fatalf("response without validators should not be cached")
+	}
+}
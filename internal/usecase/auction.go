@@ -2,26 +2,357 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
 	"jo3qma.com/yahoo_auctions/internal/domain/repository"
 )
 
+// maxBatchAuctionIDs は BatchGetAuctionSummaries で一度に指定できるオークションIDの上限です
+const maxBatchAuctionIDs = 50
+
+// maxConcurrentAuctionFetches は BatchGetAuctionSummaries・RefreshWatchlist で同時に取得する数の上限です
+const maxConcurrentAuctionFetches = 3
+
+// maxWatchlistAuctionIDs は RefreshWatchlist で一度に指定できるオークションIDの上限です
+// 取得内容が軽量なため、BatchGetAuctionSummaries よりも多く指定できます
+const maxWatchlistAuctionIDs = 100
+
+// defaultWatchlistTimeout は RefreshWatchlist の取得全体にかける時間の上限です
+// maxWatchlistAuctionIDs 件を maxConcurrentAuctionFetches 件ずつ取得すると約34回分の取得時間がかかるため、
+// ハンドラーはサーバーの書き込みのタイムアウトを解除し、代わりにこの上限で処理時間を制限します
+const defaultWatchlistTimeout = 60 * time.Second
+
 // AuctionUsecase はオークション関連のビジネスロジックを担当します
 // 単一責任の原則に従い、オークション取得のユースケースのみを扱います
 type AuctionUsecase struct {
 	repo repository.ItemRepository
+
+	// clearInvalidStartTime が true の場合、終了日時より後の開始日時を不正な値としてゼロ値にします
+	clearInvalidStartTime bool
+	// watchlistTimeout は RefreshWatchlist の取得全体の時間の上限です。0 の場合は上限なし
+	watchlistTimeout time.Duration
+}
+
+// AuctionOption はAuctionUsecaseの設定を変更する関数です
+type AuctionOption func(*AuctionUsecase)
+
+// WithInvalidStartTimeCleared は GetAuction で開始日時が終了日時より後の場合に、開始日時をゼロ値にして返すよう設定します
+// 終了日時は状態の判定や概要情報にも使われるため、終了日時ではなく開始日時の方を疑わしい値として扱います
+func WithInvalidStartTimeCleared() AuctionOption {
+	return func(u *AuctionUsecase) {
+		u.clearInvalidStartTime = true
+	}
+}
+
+// WithWatchlistTimeout は RefreshWatchlist の取得全体にかける時間の上限を設定します
+// 上限までに取得できなかったIDは、その結果の Err に context.DeadlineExceeded を記録します。0 を指定すると上限なしです
+func WithWatchlistTimeout(d time.Duration) AuctionOption {
+	return func(u *AuctionUsecase) {
+		u.watchlistTimeout = d
+	}
 }
 
 // NewAuctionUsecase は新しいAuctionUsecaseインスタンスを作成します
-func NewAuctionUsecase(repo repository.ItemRepository) *AuctionUsecase {
-	return &AuctionUsecase{
-		repo: repo,
+func NewAuctionUsecase(repo repository.ItemRepository, opts ...AuctionOption) *AuctionUsecase {
+	u := &AuctionUsecase{
+		repo:             repo,
+		watchlistTimeout: defaultWatchlistTimeout,
+	}
+	for _, opt := range opts {
+		opt(u)
 	}
+	return u
 }
 
 // GetAuction は指定されたオークションIDから商品情報を取得します
-// 将来的に「取得したデータを加工する」などのロジックが入る場所です
+// 旧形式のリンク由来の "AID=x123" や "x123?foo=bar" なども正規化してから取得します
 func (u *AuctionUsecase) GetAuction(ctx context.Context, auctionID string) (*model.Item, error) {
-	return u.repo.FetchByID(ctx, auctionID)
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := u.repo.FetchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.checkTimeRange(item), nil
+}
+
+// checkTimeRange は開始日時と終了日時がともに取得できている場合に、開始日時が終了日時以前であるかを検証します
+// 逆転している場合は日時の抽出処理の不具合（項目の取り違えやタイムゾーンの誤りなど）が疑われるため、ログに残します
+// WithInvalidStartTimeCleared が設定されている場合は、開始日時をゼロ値にしたコピーを返します（リポジトリが保持する値は変更しません）
+func (u *AuctionUsecase) checkTimeRange(item *model.Item) *model.Item {
+	info := item.AuctionInfo
+	if info == nil || info.StartTime.IsZero() || info.EndTime.IsZero() || !info.StartTime.After(info.EndTime) {
+		return item
+	}
+	log.Printf("warning: start time %s of %s is after end time %s", info.StartTime.Format(time.RFC3339), item.AuctionID, info.EndTime.Format(time.RFC3339))
+	if !u.clearInvalidStartTime {
+		return item
+	}
+
+	cleared := *item
+	clearedInfo := *info
+	clearedInfo.StartTime = time.Time{}
+	cleared.AuctionInfo = &clearedInfo
+	return &cleared
+}
+
+// GetAuctionWithFields は fields で指定した任意フィールドのみを含む商品情報を取得します
+// 説明文や画像が不要な場合に抽出処理を省略できます。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetAuctionWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.FetchByIDWithFields(ctx, id, fields)
+}
+
+// GetAuctionSummary は指定されたオークションIDの軽量な概要情報を取得します
+// 説明文や画像の抽出を省略するため、GetAuction よりも高速です。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetAuctionSummary(ctx context.Context, auctionID string) (*model.AuctionSummary, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := u.repo.FetchByIDWithFields(ctx, id, model.ItemFieldsNone)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.AuctionSummary{
+		AuctionID:    item.AuctionID,
+		Title:        item.Title,
+		CurrentPrice: item.CurrentPrice,
+		Status:       item.Status,
+	}
+	if item.AuctionInfo != nil {
+		summary.EndTime = item.AuctionInfo.EndTime
+	}
+	return summary, nil
+}
+
+// BatchGetAuctionSummaries は複数のオークションIDの概要情報を並行して取得します
+// オークションIDは GetAuction と同様に正規化します
+// 結果は auctionIDs と同じ順序で、IDが不正な場合や取得に失敗した場合はその結果の Err にエラーを記録します（他のIDの取得は継続します）
+// ID が空、または maxBatchAuctionIDs を超える場合は ErrInvalidArgument を返します
+func (u *AuctionUsecase) BatchGetAuctionSummaries(ctx context.Context, auctionIDs []string) ([]model.Result[*model.AuctionSummary], error) {
+	if len(auctionIDs) == 0 {
+		return nil, fmt.Errorf("%w: auction ids are required", ErrInvalidArgument)
+	}
+	if len(auctionIDs) > maxBatchAuctionIDs {
+		return nil, fmt.Errorf("%w: too many auction ids (%d > %d)", ErrInvalidArgument, len(auctionIDs), maxBatchAuctionIDs)
+	}
+	return fanOut(ctx, auctionIDs, maxConcurrentAuctionFetches, u.GetAuctionSummary), nil
+}
+
+// GetAuctionSnapshot は指定されたオークションIDの現在価格・入札件数・終了日時を1回の取得で返します
+// 説明文や画像の抽出を省略するため、価格の追跡用途では GetAuction よりも軽量です。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetAuctionSnapshot(ctx context.Context, auctionID string) (*model.AuctionSnapshot, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := u.repo.FetchByIDWithFields(ctx, id, model.ItemFieldsNone)
+	if err != nil {
+		return nil, err
+	}
+	return newAuctionSnapshot(item), nil
+}
+
+// RefreshWatchlist はウォッチリストの各オークションの状態・現在価格・入札件数・終了日時を並行して取得します
+// 説明文や画像の抽出は省略します。オークションIDは GetAuction と同様に正規化します
+// 結果は auctionIDs と同じ順序で、IDが不正な場合や取得に失敗した場合はその結果の Err にエラーを記録します（他のIDの取得は継続します）
+// 取得全体の時間は WithWatchlistTimeout の上限までとし、上限までに取得できなかったIDも結果の Err に記録します
+// ID が空、または maxWatchlistAuctionIDs を超える場合は ErrInvalidArgument を返します
+func (u *AuctionUsecase) RefreshWatchlist(ctx context.Context, auctionIDs []string) ([]model.Result[*model.AuctionSnapshot], error) {
+	if len(auctionIDs) == 0 {
+		return nil, fmt.Errorf("%w: auction ids are required", ErrInvalidArgument)
+	}
+	if len(auctionIDs) > maxWatchlistAuctionIDs {
+		return nil, fmt.Errorf("%w: too many auction ids (%d > %d)", ErrInvalidArgument, len(auctionIDs), maxWatchlistAuctionIDs)
+	}
+	if u.watchlistTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.watchlistTimeout)
+		defer cancel()
+	}
+	return fanOut(ctx, auctionIDs, maxConcurrentAuctionFetches, func(ctx context.Context, auctionID string) (*model.AuctionSnapshot, error) {
+		id, err := normalizeAuctionID(auctionID)
+		if err != nil {
+			return nil, err
+		}
+		item, err := u.repo.FetchByIDWithFields(ctx, id, model.ItemFieldsNone)
+		if err != nil {
+			return nil, err
+		}
+		return newAuctionSnapshot(item), nil
+	}), nil
+}
+
+// newAuctionSnapshot は商品情報からスナップショットを作成します
+func newAuctionSnapshot(item *model.Item) *model.AuctionSnapshot {
+	snapshot := &model.AuctionSnapshot{
+		AuctionID:    item.AuctionID,
+		CurrentPrice: item.CurrentPrice,
+		BidCount:     item.BidCount,
+		Status:       item.Status,
+	}
+	if item.AuctionInfo != nil {
+		snapshot.EndTime = item.AuctionInfo.EndTime
+	}
+	return snapshot
+}
+
+// GetAuctionStatus は指定されたオークションIDの状態のみを取得します
+// ウォッチリストなど、出品中かどうかだけを確認したい場合に利用します。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetAuctionStatus(ctx context.Context, auctionID string) (model.Status, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return model.StatusUnspecified, err
+	}
+	return u.repo.FetchStatus(ctx, id)
+}
+
+// GetAuctionQuestions は指定されたオークションで公開されている質問と回答を取得します
+// 質問がない場合は空スライスを返します。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetAuctionQuestions(ctx context.Context, auctionID string) ([]*model.QA, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := u.repo.FetchByIDWithFields(ctx, id, model.ItemFieldQuestions)
+	if err != nil {
+		return nil, err
+	}
+	if item.Questions == nil {
+		return []*model.QA{}, nil
+	}
+	return item.Questions, nil
+}
+
+// GetRelatedItems は指定されたオークションの詳細ページに表示される関連商品を取得します
+// 関連商品がない場合は空スライスを返します。オークションIDは GetAuction と同様に正規化します
+func (u *AuctionUsecase) GetRelatedItems(ctx context.Context, auctionID string) ([]*model.CategoryItem, error) {
+	id, err := normalizeAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := u.repo.FetchByIDWithFields(ctx, id, model.ItemFieldRelatedItems)
+	if err != nil {
+		return nil, err
+	}
+	if item.RelatedItems == nil {
+		return []*model.CategoryItem{}, nil
+	}
+	return item.RelatedItems, nil
+}
+
+// auctionIDPattern は正規化後のオークションIDの形式です（英小文字1文字 + 数字、または数字のみ）
+var auctionIDPattern = regexp.MustCompile(`^[a-z]?[0-9]+$`)
+
+// storeAuctionIDPattern はストアの商品URLの商品コードのうち、オークションIDとして扱える形式です
+// ショッピングの商品コードは数字のみの場合もあるため、旧形式の数字のみのIDとは区別できず対象外とします
+var storeAuctionIDPattern = regexp.MustCompile(`^[a-z][0-9]+$`)
+
+// auctionIDParams は古いリンクなどでオークションIDを表す既知のクエリパラメーターです（小文字で比較します）
+var auctionIDParams = []string{"aid", "auction_id"}
+
+// storeShoppingHost はストアの商品ページ（/{ストアID}/{商品コード}.html）のホストです
+const storeShoppingHost = "store.shopping.yahoo.co.jp"
+
+// auctionsHostSuffix はヤフオクのページのホストの接尾辞です（auctions.yahoo.co.jp、page.auctions.yahoo.co.jp など）
+const auctionsHostSuffix = "auctions.yahoo.co.jp"
+
+// normalizeAuctionID はオークションIDから既知の接頭辞やクエリ・フラグメントを取り除き、小文字に揃えます
+// 例: "AID=x123" -> "x123", "x123?foo=bar" -> "x123", "/jp/auction/X123/" -> "x123"
+// ストアの商品URL（"https://store.shopping.yahoo.co.jp/mystore/b123.html"、"https://auctions.yahoo.co.jp/store/mystore/item/b123"）にも対応します
+// 妥当なIDが残らない場合や、ヤフオク・ストア以外のURLの場合は ErrInvalidArgument を返します
+func normalizeAuctionID(auctionID string) (string, error) {
+	id := extractAuctionIDParam(strings.ToLower(strings.TrimSpace(auctionID)))
+
+	pattern := auctionIDPattern
+	if host, path, ok := splitURL(id); ok {
+		switch {
+		case host == storeShoppingHost:
+			// ストアの商品コードがオークションIDと一致する場合のみ、そのオークションとして扱う
+			id = path
+			pattern = storeAuctionIDPattern
+		case host == auctionsHostSuffix || strings.HasSuffix(host, "."+auctionsHostSuffix):
+			id = storeItemSegment(path)
+		default:
+			return "", fmt.Errorf("%w: auction url %q is not recognized", ErrInvalidArgument, auctionID)
+		}
+	}
+
+	if i := strings.IndexAny(id, "?#&"); i >= 0 {
+		id = id[:i]
+	}
+	id = strings.Trim(id, "/")
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	id = strings.TrimSuffix(id, ".html")
+
+	if !pattern.MatchString(id) {
+		return "", fmt.Errorf("%w: auction id %q is not valid", ErrInvalidArgument, auctionID)
+	}
+	return id, nil
+}
+
+// extractAuctionIDParam は s が auctionIDParams のいずれかの "キー=" で始まる場合、またはクエリにそのキーを含む場合にその値を返します
+// "said=" や "paid=" のように別のキーの一部に一致しただけの場合は対象とせず、s をそのまま返します
+func extractAuctionIDParam(s string) string {
+	for _, param := range auctionIDParams {
+		if v, ok := strings.CutPrefix(s, param+"="); ok {
+			return v
+		}
+	}
+
+	_, rawQuery, ok := strings.Cut(s, "?")
+	if !ok {
+		return s
+	}
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+	// 不正なエスケープを含む場合もそれ以外のパラメーターは解析されるため、エラーは無視する
+	query, _ := url.ParseQuery(rawQuery)
+	for _, param := range auctionIDParams {
+		if v := query.Get(param); v != "" {
+			return v
+		}
+	}
+	return s
+}
+
+// splitURL は "https://host/path" または "host/path" 形式の文字列をホストとパスに分割します
+// ヤフオク・ストアのホストを含まないスキームなしの文字列（"/jp/auction/x123" など）は URL とみなさず false を返します
+func splitURL(s string) (host, path string, ok bool) {
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+len("://"):]
+	} else if !strings.Contains(s, auctionsHostSuffix) && !strings.Contains(s, storeShoppingHost) {
+		return "", "", false
+	}
+	host, path, _ = strings.Cut(s, "/")
+	if i := strings.IndexAny(host, "?#"); i >= 0 {
+		host = host[:i]
+	}
+	return host, path, true
+}
+
+// storeItemSegment はストアの出品一覧のパス（"/store/{ストアID}/item/{オークションID}/..."）から、item の次のセグメントまでを返します
+// item セグメントを含まない場合は path をそのまま返します
+func storeItemSegment(path string) string {
+	segments := strings.Split(strings.SplitN(path, "?", 2)[0], "/")
+	for i, seg := range segments {
+		if seg == "item" && i+1 < len(segments) {
+			return strings.Join(segments[:i+2], "/")
+		}
+	}
+	return path
 }
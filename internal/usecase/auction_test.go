@@ -0,0 +1,468 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/memory"
+)
+
+func TestAuctionUsecase_GetAuctionSummary_mapsLightweightFields(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 12, 30, 16, 0, 10, 0, time.FixedZone("JST", 9*60*60))
+	repo := memory.NewItemRepository(&model.Item{
+		AuctionID:    "x1234567890",
+		Title:        "title",
+		CurrentPrice: 1234,
+		Status:       model.StatusActive,
+		Description:  "<p>desc</p>",
+		AuctionInfo:  &model.AuctionInformation{EndTime: end},
+	})
+	uc := NewAuctionUsecase(repo)
+
+	got, err := uc.GetAuctionSummary(context.Background(), "x1234567890")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &model.AuctionSummary{
+		AuctionID:    "x1234567890",
+		Title:        "title",
+		CurrentPrice: 1234,
+		Status:       model.StatusActive,
+		EndTime:      end,
+	}
+	if *got != *want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAuctionUsecase_GetAuction_startTimeAfterEndTime(t *testing.T) {
+	t.Parallel()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	start := time.Date(2025, 12, 31, 9, 0, 0, 0, jst)
+	end := time.Date(2025, 12, 30, 16, 0, 10, 0, jst)
+	repo := memory.NewItemRepository(&model.Item{
+		AuctionID:   "x1",
+		AuctionInfo: &model.AuctionInformation{StartTime: start, EndTime: end},
+	})
+
+	// 設定しない場合は警告のみで値はそのまま返す
+	got, err := NewAuctionUsecase(repo).GetAuction(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.AuctionInfo.StartTime.Equal(start) {
+		t.Errorf("StartTime got %v, want %v", got.AuctionInfo.StartTime, start)
+	}
+
+	got, err = NewAuctionUsecase(repo, WithInvalidStartTimeCleared()).GetAuction(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.AuctionInfo.StartTime.IsZero() || !got.AuctionInfo.EndTime.Equal(end) {
+		t.Errorf("got start %v end %v, want zero start and end %v", got.AuctionInfo.StartTime, got.AuctionInfo.EndTime, end)
+	}
+	// リポジトリが保持する値は変更しない
+	if stored, _ := repo.Get("x1"); !stored.AuctionInfo.StartTime.Equal(start) {
+		t.Errorf("stored StartTime got %v, want %v", stored.AuctionInfo.StartTime, start)
+	}
+}
+
+func TestAuctionUsecase_GetAuctionSummary_returnsRepoError(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository())
+
+	_, err := uc.GetAuctionSummary(context.Background(), "x404")
+	if !errors.Is(err, memory.ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, memory.ErrNotFound)
+	}
+}
+
+func TestAuctionUsecase_GetRelatedItems_returnsEmptySliceWhenNone(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x1"}))
+
+	got, err := uc.GetRelatedItems(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want empty slice", got)
+	}
+}
+
+func TestAuctionUsecase_GetAuctionStatus(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x1", Status: model.StatusFinished}))
+
+	got, err := uc.GetAuctionStatus(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != model.StatusFinished {
+		t.Errorf("got %v, want %v", got, model.StatusFinished)
+	}
+
+	if _, err := uc.GetAuctionStatus(context.Background(), "x404"); !errors.Is(err, memory.ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, memory.ErrNotFound)
+	}
+}
+
+func TestAuctionUsecase_GetAuctionSnapshot(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 12, 30, 16, 0, 10, 0, time.FixedZone("JST", 9*60*60))
+	repo := memory.NewItemRepository(
+		&model.Item{
+			AuctionID:    "x1",
+			CurrentPrice: 1500,
+			BidCount:     3,
+			Images:       []string{"https://example.com/1.jpg"},
+			AuctionInfo:  &model.AuctionInformation{EndTime: end},
+		},
+		&model.Item{AuctionID: "x2", CurrentPrice: 100},
+	)
+	uc := NewAuctionUsecase(repo)
+
+	cases := []struct {
+		name string
+		id   string
+		want model.AuctionSnapshot
+	}{
+		{name: "with end time", id: "x1", want: model.AuctionSnapshot{AuctionID: "x1", CurrentPrice: 1500, BidCount: 3, EndTime: end}},
+		{name: "without auction info", id: "x2", want: model.AuctionSnapshot{AuctionID: "x2", CurrentPrice: 100}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := uc.GetAuctionSnapshot(context.Background(), tc.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tc.want {
+				t.Errorf("got %+v, want %+v", *got, tc.want)
+			}
+		})
+	}
+}
+
+// watchlistRepo は取得時に指定されたフィールドと同時に取得している数を記録するフェイクです
+type watchlistRepo struct {
+	repository.ItemRepository
+
+	mu        sync.Mutex
+	fields    []model.ItemFields
+	inFlight  int
+	maxFlight int
+}
+
+func (f *watchlistRepo) FetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	f.mu.Lock()
+	f.fields = append(f.fields, fields)
+	f.inFlight++
+	f.maxFlight = max(f.maxFlight, f.inFlight)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	return f.ItemRepository.FetchByIDWithFields(ctx, auctionID, fields)
+}
+
+func TestAuctionUsecase_RefreshWatchlist(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 12, 30, 16, 0, 10, 0, time.FixedZone("JST", 9*60*60))
+	repo := &watchlistRepo{ItemRepository: memory.NewItemRepository(
+		&model.Item{AuctionID: "x1", CurrentPrice: 1500, BidCount: 3, Status: model.StatusActive, AuctionInfo: &model.AuctionInformation{EndTime: end}},
+		&model.Item{AuctionID: "x2", CurrentPrice: 800, Status: model.StatusFinished},
+		&model.Item{AuctionID: "x4", CurrentPrice: 100, Status: model.StatusActive},
+		&model.Item{AuctionID: "x5", CurrentPrice: 200, Status: model.StatusActive},
+		&model.Item{AuctionID: "x6", CurrentPrice: 300, Status: model.StatusActive},
+	)}
+	uc := NewAuctionUsecase(repo)
+
+	ids := []string{"x1", "https://page.auctions.yahoo.co.jp/jp/auction/x2", "x3", "not an id", "x4", "x5", "x6"}
+	got, err := uc.RefreshWatchlist(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("results got %d, want %d", len(got), len(ids))
+	}
+
+	want := model.AuctionSnapshot{AuctionID: "x1", CurrentPrice: 1500, BidCount: 3, EndTime: end, Status: model.StatusActive}
+	if !got[0].OK() || *got[0].Value != want {
+		t.Errorf("results[0] got %+v, want %+v", got[0], want)
+	}
+	if !got[1].OK() || got[1].Value.AuctionID != "x2" || got[1].Value.Status != model.StatusFinished {
+		t.Errorf("results[1] got %+v, want finished x2", got[1])
+	}
+	if !errors.Is(got[2].Err, memory.ErrNotFound) {
+		t.Errorf("results[2].Err got %v, want %v", got[2].Err, memory.ErrNotFound)
+	}
+	if !errors.Is(got[3].Err, ErrInvalidArgument) {
+		t.Errorf("results[3].Err got %v, want %v", got[3].Err, ErrInvalidArgument)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for _, fields := range repo.fields {
+		if fields != model.ItemFieldsNone {
+			t.Errorf("fetched with fields %b, want ItemFieldsNone", fields)
+		}
+	}
+	if repo.maxFlight > maxConcurrentAuctionFetches {
+		t.Errorf("max concurrent fetches got %d, want at most %d", repo.maxFlight, maxConcurrentAuctionFetches)
+	}
+}
+
+func TestAuctionUsecase_RefreshWatchlist_timeout(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(sellerLookupRepo{delay: time.Second}, WithWatchlistTimeout(20*time.Millisecond))
+
+	ids := []string{"x1", "x2", "x3", "x4", "x5"}
+	start := time.Now()
+	got, err := uc.RefreshWatchlist(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("refresh took %s, want it to stop at the timeout", elapsed)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("results got %d, want %d", len(got), len(ids))
+	}
+	for i, res := range got {
+		if !errors.Is(res.Err, context.DeadlineExceeded) {
+			t.Errorf("results[%d].Err got %v, want %v", i, res.Err, context.DeadlineExceeded)
+		}
+	}
+}
+
+func TestAuctionUsecase_RefreshWatchlist_validatesIDs(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository())
+
+	tooMany := make([]string, maxWatchlistAuctionIDs+1)
+	for i := range tooMany {
+		tooMany[i] = "x1"
+	}
+	for _, ids := range [][]string{nil, tooMany} {
+		if _, err := uc.RefreshWatchlist(context.Background(), ids); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("len %d: got error %v, want %v", len(ids), err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestNormalizeAuctionID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", in: "x1234567890", want: "x1234567890"},
+		{name: "uppercase", in: "X1234567890", want: "x1234567890"},
+		{name: "surrounding spaces", in: "  b1234567 ", want: "b1234567"},
+		{name: "numeric legacy id", in: "123456789", want: "123456789"},
+		{name: "aid prefix", in: "AID=x123", want: "x123"},
+		{name: "query suffix", in: "x123?foo=bar", want: "x123"},
+		{name: "fragment suffix", in: "x123#bid", want: "x123"},
+		{name: "path", in: "/jp/auction/x123/", want: "x123"},
+		{name: "legacy query string", in: "show/qanda?aID=x123&foo=bar", want: "x123"},
+		{name: "auction_id query", in: "https://page.auctions.yahoo.co.jp/jp/show/qanda?auction_id=x123", want: "x123"},
+		{name: "key ending in aid", in: "https://page.auctions.yahoo.co.jp/jp/auction/x123?said=1", want: "x123"},
+		{name: "key ending in aid without url", in: "x123?paid=1&foo=bar", want: "x123"},
+		{name: "html suffix", in: "x123.html", want: "x123"},
+		{name: "auction url", in: "https://page.auctions.yahoo.co.jp/jp/auction/x123?sc_i=share", want: "x123"},
+		{name: "store shopping url", in: "https://store.shopping.yahoo.co.jp/mystore/b123.html", want: "b123"},
+		{name: "store shopping url without scheme", in: "store.shopping.yahoo.co.jp/mystore/B123.html?sc_e=share", want: "b123"},
+		{name: "store auctions item url", in: "https://auctions.yahoo.co.jp/store/mystore/item/b123/", want: "b123"},
+		{name: "store auctions item url with trailing path", in: "https://auctions.yahoo.co.jp/store/mystore/item/b123/detail", want: "b123"},
+		{name: "store shopping numeric item code", in: "https://store.shopping.yahoo.co.jp/mystore/123456.html", wantErr: true},
+		{name: "store shopping slug", in: "https://store.shopping.yahoo.co.jp/mystore/camera-lens.html", wantErr: true},
+		{name: "store top page", in: "https://auctions.yahoo.co.jp/store/mystore/", wantErr: true},
+		{name: "unknown host", in: "https://example.com/jp/auction/x123", wantErr: true},
+		{name: "empty", in: " ", wantErr: true},
+		{name: "only prefix", in: "AID=", wantErr: true},
+		{name: "not an id", in: "camera", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := normalizeAuctionID(tc.in)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Fatalf("got error %v, want %v", err, ErrInvalidArgument)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuctionUsecase_GetAuction_normalizesID(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x123"}))
+
+	got, err := uc.GetAuction(context.Background(), "AID=X123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.AuctionID != "x123" {
+		t.Errorf("got %q, want %q", got.AuctionID, "x123")
+	}
+
+	if _, err := uc.GetAuction(context.Background(), "?foo=bar"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+// TestAuctionUsecase_normalizesIDInEveryEntryPoint は GetAuction 以外の取得でも、オークションIDを正規化してから取得することを確認します
+func TestAuctionUsecase_normalizesIDInEveryEntryPoint(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x123", Title: "title", Status: model.StatusActive}))
+	ctx := context.Background()
+	const raw = "https://page.auctions.yahoo.co.jp/jp/auction/X123?foo=bar"
+
+	calls := map[string]func(id string) error{
+		"GetAuctionSummary": func(id string) error {
+			got, err := uc.GetAuctionSummary(ctx, id)
+			if err == nil && got.Title != "title" {
+				t.Errorf("GetAuctionSummary title got %q, want %q", got.Title, "title")
+			}
+			return err
+		},
+		"GetAuctionSnapshot": func(id string) error { _, err := uc.GetAuctionSnapshot(ctx, id); return err },
+		"GetAuctionStatus": func(id string) error {
+			got, err := uc.GetAuctionStatus(ctx, id)
+			if err == nil && got != model.StatusActive {
+				t.Errorf("GetAuctionStatus got %v, want %v", got, model.StatusActive)
+			}
+			return err
+		},
+		"GetAuctionQuestions": func(id string) error { _, err := uc.GetAuctionQuestions(ctx, id); return err },
+		"GetRelatedItems":     func(id string) error { _, err := uc.GetRelatedItems(ctx, id); return err },
+	}
+	for name, call := range calls {
+		if err := call(raw); err != nil {
+			t.Errorf("%s(%q): unexpected error: %v", name, raw, err)
+		}
+		if err := call("not an id"); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%s: got error %v, want %v", name, err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestAuctionUsecase_BatchGetAuctionSummaries_normalizesIDs(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x1", Title: "one"}))
+
+	got, err := uc.BatchGetAuctionSummaries(context.Background(), []string{"AID=X1", "https://page.auctions.yahoo.co.jp/jp/auction/x1/", "not an id"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range 2 {
+		if !got[i].OK() || got[i].Value.AuctionID != "x1" {
+			t.Errorf("results[%d] got %+v, want x1", i, got[i])
+		}
+	}
+	if !errors.Is(got[2].Err, ErrInvalidArgument) {
+		t.Errorf("results[2].Err got %v, want %v", got[2].Err, ErrInvalidArgument)
+	}
+}
+
+func TestAuctionUsecase_BatchGetAuctionSummaries_partialSuccess(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(
+		&model.Item{AuctionID: "x1", Title: "one"},
+		&model.Item{AuctionID: "x3", Title: "three"},
+	))
+
+	got, err := uc.BatchGetAuctionSummaries(context.Background(), []string{"x1", "x2", "x3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("results got %d, want 3", len(got))
+	}
+	for i, id := range []string{"x1", "x2", "x3"} {
+		if got[i].ID != id {
+			t.Errorf("results[%d].ID got %q, want %q", i, got[i].ID, id)
+		}
+	}
+	if !got[0].OK() || got[0].Value.Title != "one" {
+		t.Errorf("results[0] got %+v", got[0])
+	}
+	if !errors.Is(got[1].Err, memory.ErrNotFound) {
+		t.Errorf("results[1].Err got %v, want %v", got[1].Err, memory.ErrNotFound)
+	}
+	if !got[2].OK() || got[2].Value.Title != "three" {
+		t.Errorf("results[2] got %+v", got[2])
+	}
+}
+
+func TestAuctionUsecase_BatchGetAuctionSummaries_validatesIDs(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository())
+
+	tooMany := make([]string, maxBatchAuctionIDs+1)
+	for i := range tooMany {
+		tooMany[i] = "x1"
+	}
+	for _, ids := range [][]string{nil, tooMany} {
+		if _, err := uc.BatchGetAuctionSummaries(context.Background(), ids); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("len %d: got error %v, want %v", len(ids), err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestAuctionUsecase_GetAuctionQuestions_returnsEmptySliceWhenNone(t *testing.T) {
+	t.Parallel()
+
+	uc := NewAuctionUsecase(memory.NewItemRepository(&model.Item{AuctionID: "x1"}))
+
+	got, err := uc.GetAuctionQuestions(context.Background(), "x1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want empty slice", got)
+	}
+}
@@ -2,25 +2,639 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
 	"jo3qma.com/yahoo_auctions/internal/domain/repository"
 )
 
+// maxConcurrentCategoryFetches は複数カテゴリ取得時の同時リクエスト数の上限です
+// ヤフオク側への負荷を抑えるため、カテゴリ数に関わらずこの数までしか並行取得しません
+const maxConcurrentCategoryFetches = 3
+
+// maxConcurrentSellerLookups は出品者の評価で絞り込む際に、商品詳細を同時に取得する数の上限です
+const maxConcurrentSellerLookups = 3
+
+// defaultSellerLookupTimeout は出品者の評価で絞り込む際の、1ページ分の商品詳細の取得全体の時間の上限です
+// 1回のRPCの中で行うため、サーバーの書き込みのタイムアウト（15秒）より短くしています
+const defaultSellerLookupTimeout = 10 * time.Second
+
+// デフォルトのページ間の待機時間の範囲です
+// 一定間隔のアクセスは機械的なパターンとして検出されやすいため、範囲内でランダムに待機します
+const (
+	defaultMinPageDelay = 1 * time.Second
+	defaultMaxPageDelay = 3 * time.Second
+)
+
 // CategoryUsecase はカテゴリ検索関連のビジネスロジックを担当します
 type CategoryUsecase struct {
 	repo repository.CategoryItemRepository
+	now  func() time.Time
+
+	// items は出品者の評価で絞り込む際に商品詳細を取得するリポジトリです。nil の場合は絞り込みに対応しません
+	items repository.ItemRepository
+	// sellerLookupTimeout は出品者の評価で絞り込む際の商品詳細の取得全体の時間の上限です。0 の場合は上限なし
+	sellerLookupTimeout time.Duration
+
+	minPageDelay time.Duration
+	maxPageDelay time.Duration
+
+	// aggregateTimeout は複数ページを連続取得する処理全体の時間の上限です。0 の場合は上限なし
+	aggregateTimeout time.Duration
+
+	// shortPageSize は1ページあたりの商品数です。0 より大きい場合、後続の商品があるのに商品数が満たないページを1回だけ取得し直します
+	shortPageSize int64
+
+	// allowedCategories が空でない場合は、含まれるカテゴリIDのみ取得を許可します
+	allowedCategories map[string]bool
+	// deniedCategories に含まれるカテゴリIDは、allowedCategories に含まれていても取得を拒否します
+	deniedCategories map[string]bool
+}
+
+// CategoryOption はCategoryUsecaseの設定を変更する関数です
+type CategoryOption func(*CategoryUsecase)
+
+// WithPageDelay は複数ページを連続取得する際のページ間の待機時間の範囲を設定します
+// 実際の待機時間は [minDelay, maxDelay] の範囲でランダムに決まります。0 を指定すると待機しません
+func WithPageDelay(minDelay, maxDelay time.Duration) CategoryOption {
+	return func(u *CategoryUsecase) {
+		if maxDelay < minDelay {
+			maxDelay = minDelay
+		}
+		u.minPageDelay = minDelay
+		u.maxPageDelay = maxDelay
+	}
+}
+
+// WithAggregateTimeout は GetCategoryItemsRange / EachCategoryPage の処理全体の時間の上限を設定します
+// 1リクエストごとのタイムアウトとは別に、ページ数が多い場合でも d を超えたら取得を打ち切ります。0 を指定すると上限なしです
+func WithAggregateTimeout(d time.Duration) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.aggregateTimeout = d
+	}
+}
+
+// WithShortPageRetry は GetCategoryItemsRange / EachCategoryPage で、後続の商品があるにもかかわらず
+// 商品数が pageSize に満たないページを取得した場合に、同じページを1回だけ取得し直すよう設定します
+// 後続の商品の有無は HasNext または総件数（TotalCount）とページの位置から判定するため、総件数を取得できないページは対象外です
+// 取得元が一時的に一部の商品しか返さない場合に、商品を取りこぼさないためのものです。pageSize にはリポジトリの1ページあたりの商品数を指定します
+func WithShortPageRetry(pageSize int64) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.shortPageSize = pageSize
+	}
+}
+
+// WithSellerLookup は MinSellerRatingPercentage による絞り込みで出品者を調べるためのリポジトリを設定します
+// 絞り込みを指定した場合のみ、一覧の商品ごとに商品詳細を取得します
+func WithSellerLookup(items repository.ItemRepository) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.items = items
+	}
+}
+
+// WithSellerLookupTimeout は MinSellerRatingPercentage による絞り込みで、1ページ分の商品詳細の取得全体にかける時間の上限を設定します
+// 上限に達した時点で判定できていない商品は除外し、結果の Partial を true にします。0 を指定すると上限なしです
+func WithSellerLookupTimeout(d time.Duration) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.sellerLookupTimeout = d
+	}
+}
+
+// WithAllowedCategories は取得を許可するカテゴリIDを設定します
+// 空の場合はすべてのカテゴリを許可します
+func WithAllowedCategories(categoryIDs []string) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.allowedCategories = categorySet(categoryIDs)
+	}
+}
+
+// WithDeniedCategories は取得を拒否するカテゴリIDを設定します
+// 共有環境でアダルトカテゴリなどへのアクセスを防ぐ場合に利用します
+func WithDeniedCategories(categoryIDs []string) CategoryOption {
+	return func(u *CategoryUsecase) {
+		u.deniedCategories = categorySet(categoryIDs)
+	}
+}
+
+// categorySet はカテゴリIDの一覧を、前後の空白を除いた集合に変換します
+func categorySet(categoryIDs []string) map[string]bool {
+	set := make(map[string]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			set[id] = true
+		}
+	}
+	return set
 }
 
 // NewCategoryUsecase は新しいCategoryUsecaseインスタンスを作成します
-func NewCategoryUsecase(repo repository.CategoryItemRepository) *CategoryUsecase {
-	return &CategoryUsecase{
-		repo: repo,
+func NewCategoryUsecase(repo repository.CategoryItemRepository, opts ...CategoryOption) *CategoryUsecase {
+	u := &CategoryUsecase{
+		repo:                repo,
+		now:                 time.Now,
+		minPageDelay:        defaultMinPageDelay,
+		maxPageDelay:        defaultMaxPageDelay,
+		sellerLookupTimeout: defaultSellerLookupTimeout,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
 // GetCategoryItems は指定されたカテゴリIDから商品一覧を取得します
-func (u *CategoryUsecase) GetCategoryItems(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error) {
-	// ここでバリデーションや追加のビジネスロジックがあれば記述します
-	return u.repo.FetchByCategory(ctx, categoryID, page)
+func (u *CategoryUsecase) GetCategoryItems(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	categoryID, err := u.authorizeCategoryID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	opts, err = normalizeSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.repo.FetchByCategory(ctx, categoryID, page, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.EndingSoon {
+		p = u.applyEndingSoon(p)
+	}
+	p = u.filterNewlyListed(p, opts.NewlyListedWithin)
+	p = filterBargains(p, opts.MaxImmediatePriceRatio)
+	if p, err = u.applySellerRatingFilter(ctx, p, opts.MinSellerRatingPercentage); err != nil {
+		return nil, err
+	}
+	return model.SortItems(p, opts.SortBy), nil
+}
+
+// GetCategoryItemCount は指定されたカテゴリIDの商品の総数のみを取得します
+// ダッシュボードなど件数だけが必要な場合に、GetCategoryItems よりも軽量に取得できます
+func (u *CategoryUsecase) GetCategoryItemCount(ctx context.Context, categoryID string) (int64, error) {
+	categoryID, err := u.authorizeCategoryID(categoryID)
+	if err != nil {
+		return 0, err
+	}
+	return u.repo.FetchItemCount(ctx, categoryID)
+}
+
+// GetCategoryItemsByOffset は指定されたカテゴリIDから、offset 件目（0 始まり）以降の商品を limit 件取得します
+// クロールの再開位置をページ番号ではなく取得位置で保存したい場合に利用します
+func (u *CategoryUsecase) GetCategoryItemsByOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	categoryID, err := u.authorizeCategoryID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative: %w", ErrInvalidArgument)
+	}
+	if !slices.Contains(model.CategoryPageLimits, limit) {
+		return nil, fmt.Errorf("limit must be one of %v: %w", model.CategoryPageLimits, ErrInvalidArgument)
+	}
+	opts, err = normalizeSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.repo.FetchByCategoryOffset(ctx, categoryID, offset, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.EndingSoon {
+		p = u.applyEndingSoon(p)
+	}
+	p = u.filterNewlyListed(p, opts.NewlyListedWithin)
+	p = filterBargains(p, opts.MaxImmediatePriceRatio)
+	if p, err = u.applySellerRatingFilter(ctx, p, opts.MinSellerRatingPercentage); err != nil {
+		return nil, err
+	}
+	return model.SortItems(p, opts.SortBy), nil
+}
+
+// applySellerRatingFilter は出品者の良い評価の割合が minPercentage 未満の商品を除外します
+// minPercentage が 0 の場合は何もしません。商品ごとに詳細を取得するため、同時取得数は maxConcurrentSellerLookups に、
+// 取得全体の時間は WithSellerLookupTimeout の上限に制限します
+// 評価のない出品者（割合が0）の商品は条件を満たさないものとして除外します。詳細の取得に失敗した（上限に達した場合を含む）商品も
+// 判定できないため除外し、結果の Partial を true にします。すべての商品で取得に失敗した場合はエラーを返します
+func (u *CategoryUsecase) applySellerRatingFilter(ctx context.Context, p *model.CategoryItemsPage, minPercentage float64) (*model.CategoryItemsPage, error) {
+	if minPercentage <= 0 || len(p.Items) == 0 {
+		return p, nil
+	}
+	if u.items == nil {
+		return nil, fmt.Errorf("%w: seller rating filter is not available", ErrInvalidArgument)
+	}
+
+	ids := make([]string, len(p.Items))
+	for i, item := range p.Items {
+		ids[i] = item.AuctionID
+	}
+	lookupCtx := ctx
+	if u.sellerLookupTimeout > 0 {
+		var cancel context.CancelFunc
+		lookupCtx, cancel = context.WithTimeout(ctx, u.sellerLookupTimeout)
+		defer cancel()
+	}
+	sellers := fanOut(lookupCtx, ids, maxConcurrentSellerLookups, func(ctx context.Context, id string) (*model.Seller, error) {
+		detail, err := u.items.FetchByIDWithFields(ctx, id, model.ItemFieldSeller)
+		if err != nil {
+			return nil, err
+		}
+		return detail.Seller, nil
+	})
+
+	// キャンセルされた場合、未判定の商品が除外されたページを返さないようにエラーとします
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filtered := *p
+	filtered.Items = make([]*model.CategoryItem, 0, len(p.Items))
+	var (
+		failed   int
+		firstErr error
+	)
+	for i, item := range p.Items {
+		if !sellers[i].OK() {
+			failed++
+			if firstErr == nil {
+				firstErr = sellers[i].Err
+			}
+			continue
+		}
+		if seller := sellers[i].Value; seller != nil && seller.RatingPercentage >= minPercentage {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	if failed == len(p.Items) {
+		// 空のページを返すと「条件に合う商品がない」と区別できないため、エラーとする
+		if err := lookupCtx.Err(); err != nil {
+			firstErr = fmt.Errorf("seller lookup exceeded %s: %w", u.sellerLookupTimeout, err)
+		}
+		return nil, fmt.Errorf("seller lookup failed for all %d items: %w", failed, firstErr)
+	}
+	if failed > 0 {
+		log.Printf("warning: seller lookup failed for %d of %d items, excluding them: %v", failed, len(p.Items), firstErr)
+		filtered.Partial = true
+	}
+	return &filtered, nil
+}
+
+// filterNewlyListed は開始日時が within より前の商品を除外したページを返します
+// 取得元の新着フィルタは24時間単位のため、それより短い期間を取得したページ内で絞り込みます
+// within が 0 の場合は何もしません。開始日時が不明な商品は判定できないため残し、p 自体は変更しません
+func (u *CategoryUsecase) filterNewlyListed(p *model.CategoryItemsPage, within time.Duration) *model.CategoryItemsPage {
+	if within <= 0 {
+		return p
+	}
+	since := u.now().Add(-within)
+
+	filtered := *p
+	filtered.Items = make([]*model.CategoryItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		if item.StartTime.IsZero() || !item.StartTime.Before(since) {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	return &filtered
+}
+
+// filterBargains は即決価格があり、現在価格が「即決価格 × maxRatio」未満の商品のみを残したページを返します
+// maxRatio が 0 の場合は何もしません。取得済みのページ内でのみ絞り込み、p 自体は変更しません
+func filterBargains(p *model.CategoryItemsPage, maxRatio float64) *model.CategoryItemsPage {
+	if maxRatio <= 0 {
+		return p
+	}
+
+	filtered := *p
+	filtered.Items = make([]*model.CategoryItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		if item.ImmediatePrice > 0 && float64(item.CurrentPrice) < float64(item.ImmediatePrice)*maxRatio {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	return &filtered
+}
+
+// applyEndingSoon は終了済みの商品を除外し、終了日時の昇順に並べ替えます
+// 取得元の並び順を補強するためのもので、終了日時が不明な商品は末尾に置きます
+func (u *CategoryUsecase) applyEndingSoon(p *model.CategoryItemsPage) *model.CategoryItemsPage {
+	now := u.now()
+
+	active := make([]*model.CategoryItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		if !item.EndTime.IsZero() && !item.EndTime.After(now) {
+			continue
+		}
+		active = append(active, item)
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		a, b := active[i].EndTime, active[j].EndTime
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+
+	filtered := *p
+	filtered.Items = active
+	return &filtered
+}
+
+// GetMultiCategoryItems は複数のカテゴリIDの商品一覧を並行して取得し、1つのページに統合します
+// 商品は categoryIDs の順に並べ、AuctionID が重複するものは最初の1件のみ残します
+// TotalCount は各カテゴリの総数の合計であり、カテゴリ間の重複を含む概算値です
+// 一部のカテゴリの取得に失敗した場合も他のカテゴリの取得は継続し、取得できたページのみを統合します
+// 失敗したカテゴリIDは結果の FailedCategoryIDs に記録し、Partial を true にします。すべてのカテゴリで失敗した場合はエラーを返します
+func (u *CategoryUsecase) GetMultiCategoryItems(ctx context.Context, categoryIDs []string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	normalized := make([]string, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		id, err := u.authorizeCategoryID(categoryID)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = id
+	}
+	categoryIDs = normalized
+	opts, err := normalizeSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := fanOut(ctx, categoryIDs, maxConcurrentCategoryFetches, func(ctx context.Context, categoryID string) (*model.CategoryItemsPage, error) {
+		return u.repo.FetchByCategory(ctx, categoryID, page, opts)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pages := make([]*model.CategoryItemsPage, 0, len(results))
+	var (
+		failed   []string
+		firstErr error
+	)
+	for _, res := range results {
+		if !res.OK() {
+			log.Printf("warning: failed to fetch category %s, merging the other categories: %v", res.ID, res.Err)
+			failed = append(failed, res.ID)
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+			continue
+		}
+		pages = append(pages, res.Value)
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("failed to fetch all %d categories: %w", len(failed), firstErr)
+	}
+
+	merged := mergeCategoryPages(pages)
+	if len(failed) > 0 {
+		// 失敗したカテゴリに商品があった可能性があるため、該当なしとはしない
+		merged.NoResults = false
+		merged.Partial = true
+		merged.FailedCategoryIDs = failed
+	}
+	if opts.EndingSoon {
+		merged = u.applyEndingSoon(merged)
+	}
+	merged = u.filterNewlyListed(merged, opts.NewlyListedWithin)
+	merged = filterBargains(merged, opts.MaxImmediatePriceRatio)
+	if merged, err = u.applySellerRatingFilter(ctx, merged, opts.MinSellerRatingPercentage); err != nil {
+		return nil, err
+	}
+	return model.SortItems(merged, opts.SortBy), nil
+}
+
+// GetCategoryItemsRange は fromPage から toPage まで（両端を含む）のページを順に取得し、1つのページに統合します
+// ページ間ではランダムな待機（ジッター）を挟み、次のページがない場合はその時点で取得を終了します
+// TotalCount と NoResults は最初のページの値、HasNext は最後に取得したページの値となります
+// 時間の上限（WithAggregateTimeout）に達した場合は、それまでに取得した商品を Truncated を true にして返します
+func (u *CategoryUsecase) GetCategoryItemsRange(ctx context.Context, categoryID string, fromPage, toPage int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	merged := &model.CategoryItemsPage{}
+	err := u.EachCategoryPage(ctx, categoryID, fromPage, toPage, opts, func(page int64, p *model.CategoryItemsPage) error {
+		if page == fromPage {
+			merged.TotalCount = p.TotalCount
+			merged.NoResults = p.NoResults
+		}
+		merged.Items = append(merged.Items, p.Items...)
+		merged.HasNext = p.HasNext
+		merged.Partial = merged.Partial || p.Partial
+		return nil
+	})
+	if errors.Is(err, ErrTruncated) {
+		merged.Truncated = true
+	} else if err != nil {
+		return nil, err
+	}
+
+	if opts.EndingSoon {
+		merged = u.applyEndingSoon(merged)
+	}
+	// 各ページで並べ替えた結果を連結しただけでは範囲全体の順序にならないため、統合後に並べ替え直す
+	return model.SortItems(merged, opts.SortBy), nil
+}
+
+// EachCategoryPage は fromPage から toPage まで（両端を含む）のページを順に取得し、取得するたびに fn を呼び出します
+// 全ページの取得を待たずに結果を書き出したい場合（CSV出力など）に利用します
+// ページ間の待機と終了条件は GetCategoryItemsRange と同じで、fn がエラーを返した場合はその時点で終了します
+// EndingSoon による並べ替えは行わないため、必要な場合は呼び出し側で扱います
+// 時間の上限（WithAggregateTimeout）に達した場合は、それまでのページを fn に渡したうえで ErrTruncated を返します
+func (u *CategoryUsecase) EachCategoryPage(ctx context.Context, categoryID string, fromPage, toPage int64, opts model.CategorySearchOptions, fn func(page int64, p *model.CategoryItemsPage) error) error {
+	categoryID, err := u.authorizeCategoryID(categoryID)
+	if err != nil {
+		return err
+	}
+	if fromPage < 0 || toPage < fromPage {
+		return fmt.Errorf("%w: invalid page range %d-%d", ErrInvalidArgument, fromPage, toPage)
+	}
+	opts, err = normalizeSearchOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	if u.aggregateTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, u.aggregateTimeout,
+			fmt.Errorf("%w: exceeded %s", ErrTruncated, u.aggregateTimeout))
+		defer cancel()
+	}
+
+	for page := fromPage; page <= toPage; page++ {
+		if page > fromPage {
+			if err := u.waitBetweenPages(ctx); err != nil {
+				return truncatedErr(ctx, err)
+			}
+		}
+
+		p, err := u.fetchPage(ctx, categoryID, page, opts)
+		if err != nil {
+			return truncatedErr(ctx, err)
+		}
+		hasNext := p.HasNext
+		p = u.filterNewlyListed(p, opts.NewlyListedWithin)
+		p = filterBargains(p, opts.MaxImmediatePriceRatio)
+		if p, err = u.applySellerRatingFilter(ctx, p, opts.MinSellerRatingPercentage); err != nil {
+			return truncatedErr(ctx, err)
+		}
+		p = model.SortItems(p, opts.SortBy)
+		if err := fn(page, p); err != nil {
+			return err
+		}
+
+		if !hasNext {
+			break
+		}
+	}
+	return nil
+}
+
+// fetchPage は page 番目のページを取得します
+// WithShortPageRetry が設定されている場合、後続の商品があるのに商品数が足りないページは1回だけ取得し直し、
+// 取得し直した結果を返します（再取得に失敗した場合はそのエラーを返します）
+func (u *CategoryUsecase) fetchPage(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	p, err := u.repo.FetchByCategory(ctx, categoryID, page, opts)
+	if err != nil || u.shortPageSize <= 0 || int64(len(p.Items)) >= u.shortPageSize || !u.hasItemsAfter(p, page) {
+		return p, err
+	}
+	log.Printf("warning: page %d of category %s has only %d of %d items but more items follow, retrying once", page, categoryID, len(p.Items), u.shortPageSize)
+	return u.repo.FetchByCategory(ctx, categoryID, page, opts)
+}
+
+// hasItemsAfter は page 番目のページより後に商品があるかを返します
+// 商品数による HasNext の簡易判定は商品数が足りないページでは常に false になるため、総件数とページの位置からも判定します
+func (u *CategoryUsecase) hasItemsAfter(p *model.CategoryItemsPage, page int64) bool {
+	return p.HasNext || (page+1)*u.shortPageSize < p.TotalCount
+}
+
+// truncatedErr は ctx が時間の上限（WithAggregateTimeout）によって終了していれば ErrTruncated を、そうでなければ err を返します
+func truncatedErr(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrTruncated) {
+		return cause
+	}
+	return err
+}
+
+// waitBetweenPages はページ間の待機時間だけ待機します
+// 待機中に ctx がキャンセルされた場合は即座に ctx.Err() を返します
+func (u *CategoryUsecase) waitBetweenPages(ctx context.Context) error {
+	d := jitter(u.minPageDelay, u.maxPageDelay)
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// jitter は [minDelay, maxDelay] の範囲のランダムな時間を返します
+func jitter(minDelay, maxDelay time.Duration) time.Duration {
+	if maxDelay <= minDelay {
+		return minDelay
+	}
+	return minDelay + rand.N(maxDelay-minDelay+1)
+}
+
+// mergeCategoryPages は複数のページを AuctionID で重複排除しながら1つに統合します
+func mergeCategoryPages(pages []*model.CategoryItemsPage) *model.CategoryItemsPage {
+	// NoResults はすべてのカテゴリが該当なしの場合のみ true とします
+	merged := &model.CategoryItemsPage{NoResults: len(pages) > 0}
+	seen := make(map[string]bool)
+
+	for _, p := range pages {
+		if p == nil {
+			continue
+		}
+		merged.TotalCount += p.TotalCount
+		merged.HasNext = merged.HasNext || p.HasNext
+		merged.NoResults = merged.NoResults && p.NoResults
+
+		for _, item := range p.Items {
+			if seen[item.AuctionID] {
+				continue
+			}
+			seen[item.AuctionID] = true
+			merged.Items = append(merged.Items, item)
+		}
+	}
+
+	return merged
+}
+
+// authorizeCategoryID はカテゴリIDを正規化し、設定により取得が許可されているかを検証します
+// 許可されていない場合は、ネットワークアクセス前に ErrPermissionDenied を返します
+func (u *CategoryUsecase) authorizeCategoryID(categoryID string) (string, error) {
+	id, err := normalizeCategoryID(categoryID)
+	if err != nil {
+		return "", err
+	}
+	if u.deniedCategories[id] || (len(u.allowedCategories) > 0 && !u.allowedCategories[id]) {
+		return "", fmt.Errorf("%w: category %s is not allowed", ErrPermissionDenied, id)
+	}
+	return id, nil
+}
+
+// normalizeCategoryID はカテゴリIDの前後の空白を除去し、数字のみで構成されているかを検証します
+// ヤフオクのカテゴリIDは数値のため、それ以外はネットワークアクセス前に ErrInvalidArgument とします
+func normalizeCategoryID(categoryID string) (string, error) {
+	id := strings.TrimSpace(categoryID)
+	if id == "" {
+		return "", fmt.Errorf("%w: category id is required", ErrInvalidArgument)
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: category id %q must be numeric", ErrInvalidArgument, categoryID)
+		}
+	}
+	return id, nil
+}
+
+// normalizeSearchOptions は検索条件の前後の空白を除去し、空の除外キーワードを取り除きます
+// 負の期間など不正な条件の場合は ErrInvalidArgument を返します
+func normalizeSearchOptions(opts model.CategorySearchOptions) (model.CategorySearchOptions, error) {
+	if opts.NewlyListedWithin < 0 {
+		return opts, fmt.Errorf("%w: newly listed within must not be negative", ErrInvalidArgument)
+	}
+	if opts.NewlyListedWithin > model.MaxNewlyListedWithin {
+		// 取得元の新着フィルタより長い期間は絞り込めないため、条件を無視せずに拒否する
+		return opts, fmt.Errorf("%w: newly listed within must not exceed %s", ErrInvalidArgument, model.MaxNewlyListedWithin)
+	}
+	if opts.MinSellerRatingPercentage < 0 || opts.MinSellerRatingPercentage > 100 {
+		return opts, fmt.Errorf("%w: min seller rating percentage must be between 0 and 100", ErrInvalidArgument)
+	}
+	if opts.MaxImmediatePriceRatio < 0 || opts.MaxImmediatePriceRatio > 1 {
+		return opts, fmt.Errorf("%w: max immediate price ratio must be between 0 and 1", ErrInvalidArgument)
+	}
+	if !opts.SortBy.Valid() {
+		return opts, fmt.Errorf("%w: unknown sort key %d", ErrInvalidArgument, opts.SortBy)
+	}
+	if !opts.SellerType.Valid() {
+		return opts, fmt.Errorf("%w: unknown seller type %d", ErrInvalidArgument, opts.SellerType)
+	}
+
+	opts.Keyword = strings.TrimSpace(opts.Keyword)
+
+	var excludes []string
+	for _, kw := range opts.ExcludeKeywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			excludes = append(excludes, kw)
+		}
+	}
+	opts.ExcludeKeywords = excludes
+
+	return opts, nil
 }
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// CategoryIterator はカテゴリの商品を1件ずつ返すイテレーターです
+// 商品を返し切ったときに次のページを取得するため、必要な分だけリクエストを送信します
+// ページ番号の計算や HasNext による終了判定、ページ間の待機は CategoryIterator が行います
+// 複数のgoroutineから同時に利用することはできません
+type CategoryIterator struct {
+	uc         *CategoryUsecase
+	categoryID string
+	opts       model.CategorySearchOptions
+
+	page    int64                 // 次に取得するページ番号（0 始まり）
+	buf     []*model.CategoryItem // 取得済みでまだ返していない商品
+	seen    map[string]bool       // 返した商品の AuctionID。ページの境界で商品がずれた場合の重複を除く
+	fetched bool                  // 1ページ以上取得したか。2ページ目以降の取得前にのみ待機する
+	done    bool                  // 最後のページを取得したか
+}
+
+// NewCategoryIterator は categoryID の商品を先頭のページから順に返す CategoryIterator を作成します
+// カテゴリIDと検索条件はここで検証し、不正な場合はリクエストを送信せずにエラーを返します
+// 絞り込みと並べ替えはページごとに EachCategoryPage と同じ方法で適用します
+func (u *CategoryUsecase) NewCategoryIterator(categoryID string, opts model.CategorySearchOptions) (*CategoryIterator, error) {
+	categoryID, err := u.authorizeCategoryID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	opts, err = normalizeSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CategoryIterator{
+		uc:         u,
+		categoryID: categoryID,
+		opts:       opts,
+		seen:       make(map[string]bool),
+	}, nil
+}
+
+// Next は次の商品を返します。すべての商品を返し終えた場合は ErrIteratorDone を返します
+// ページの取得やページ間の待機中に ctx がキャンセルされた場合はそのエラーを返します
+// 取得に失敗した場合もページは進まないため、再度 Next を呼び出すと同じページの取得からやり直します
+func (it *CategoryIterator) Next(ctx context.Context) (*model.CategoryItem, error) {
+	for len(it.buf) == 0 {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+		if err := it.fetchNextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	item := it.buf[0]
+	it.buf = it.buf[1:]
+	return item, nil
+}
+
+// fetchNextPage は次のページを取得し、未返却の商品を buf に設定します
+func (it *CategoryIterator) fetchNextPage(ctx context.Context) error {
+	if it.fetched {
+		if err := it.uc.waitBetweenPages(ctx); err != nil {
+			return err
+		}
+	}
+
+	p, err := it.uc.fetchPage(ctx, it.categoryID, it.page, it.opts)
+	if err != nil {
+		return err
+	}
+	// 次のページがあると判定されても商品が1件もない場合は、それ以上取得しても結果がないため終了とする
+	hasNext := p.HasNext && len(p.Items) > 0
+	p = filterBargains(p, it.opts.MaxImmediatePriceRatio)
+	if p, err = it.uc.applySellerRatingFilter(ctx, p, it.opts.MinSellerRatingPercentage); err != nil {
+		return err
+	}
+	p = model.SortItems(p, it.opts.SortBy)
+
+	it.fetched = true
+	it.page++
+	it.done = !hasNext
+	for _, item := range p.Items {
+		if it.seen[item.AuctionID] {
+			continue
+		}
+		it.seen[item.AuctionID] = true
+		it.buf = append(it.buf, item)
+	}
+	return nil
+}
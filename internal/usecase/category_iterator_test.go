@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+func TestCategoryIterator_pagesThroughCategory(t *testing.T) {
+	t.Parallel()
+
+	var fetched []int64
+	repo := pagedCategoryRepo{
+		pages: []*model.CategoryItemsPage{
+			{Items: []*model.CategoryItem{{AuctionID: "a"}, {AuctionID: "b"}}, HasNext: true},
+			{Items: []*model.CategoryItem{{AuctionID: "b"}, {AuctionID: "c"}}, HasNext: true},
+			{Items: []*model.CategoryItem{{AuctionID: "d"}}, HasNext: false},
+			{Items: []*model.CategoryItem{{AuctionID: "e"}}},
+		},
+		fetched: &fetched,
+	}
+	uc := NewCategoryUsecase(repo, WithPageDelay(0, 0))
+
+	it, err := uc.NewCategoryIterator("1", model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for {
+		item, err := it.Next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, item.AuctionID)
+	}
+
+	// ページの境界で重複した商品は1回だけ返す
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items got %v, want %v", got, want)
+	}
+	if want := []int64{0, 1, 2}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched pages got %v, want %v", fetched, want)
+	}
+
+	// 終了後に呼び出しても追加のリクエストは行わない
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+		t.Errorf("got error %v, want %v", err, ErrIteratorDone)
+	}
+	if len(fetched) != 3 {
+		t.Errorf("fetched %d pages after exhaustion, want 3", len(fetched))
+	}
+}
+
+func TestCategoryIterator_stopsOnEmptyPage(t *testing.T) {
+	t.Parallel()
+
+	repo := pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+		{HasNext: true},
+		{Items: []*model.CategoryItem{{AuctionID: "a"}}},
+	}}
+	uc := NewCategoryUsecase(repo, WithPageDelay(0, 0))
+
+	it, err := uc.NewCategoryIterator("1", model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+		t.Errorf("got error %v, want %v", err, ErrIteratorDone)
+	}
+}
+
+func TestCategoryIterator_respectsCancellationDuringDelay(t *testing.T) {
+	t.Parallel()
+
+	repo := pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+		{Items: []*model.CategoryItem{{AuctionID: "a"}}, HasNext: true},
+		{Items: []*model.CategoryItem{{AuctionID: "b"}}},
+	}}
+	uc := NewCategoryUsecase(repo, WithPageDelay(time.Hour, time.Hour))
+
+	it, err := uc.NewCategoryIterator("1", model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// 最初のページは待機せずに取得する
+	if item, err := it.Next(ctx); err != nil || item.AuctionID != "a" {
+		t.Fatalf("got %v, %v, want a", item, err)
+	}
+	if _, err := it.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCategoryUsecase_NewCategoryIterator_validatesCategoryID(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(pagedCategoryRepo{})
+	if _, err := uc.NewCategoryIterator("abc", model.CategorySearchOptions{}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
@@ -3,10 +3,20 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/memory"
+	"jo3qma.com/yahoo_auctions/internal/infrastructure/yahoo"
 )
 
 type fakeCategoryRepo struct {
@@ -14,10 +24,22 @@ type fakeCategoryRepo struct {
 	err  error
 }
 
-func (f fakeCategoryRepo) FetchByCategory(ctx context.Context, categoryID string, page int64) (*model.CategoryItemsPage, error) {
+func (f fakeCategoryRepo) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
 	return f.page, f.err
 }
 
+func (f fakeCategoryRepo) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	return f.FetchByCategory(ctx, categoryID, offset/limit, opts)
+}
+
+func (f fakeCategoryRepo) FetchItemCount(ctx context.Context, categoryID string) (int64, error) {
+	p, err := f.FetchByCategory(ctx, categoryID, 0, model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return p.TotalCount, nil
+}
+
 func TestCategoryUsecase_GetCategoryItems_delegatesToRepo(t *testing.T) {
 	t.Parallel()
 
@@ -32,7 +54,7 @@ func TestCategoryUsecase_GetCategoryItems_delegatesToRepo(t *testing.T) {
 	repo := fakeCategoryRepo{page: expectedPage}
 	uc := NewCategoryUsecase(repo)
 
-	got, err := uc.GetCategoryItems(context.Background(), "cat1", 1)
+	got, err := uc.GetCategoryItems(context.Background(), "2084261685", 1, model.CategorySearchOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,8 +71,940 @@ func TestCategoryUsecase_GetCategoryItems_returnsRepoError(t *testing.T) {
 	repo := fakeCategoryRepo{err: repoErr}
 	uc := NewCategoryUsecase(repo)
 
-	_, err := uc.GetCategoryItems(context.Background(), "cat1", 1)
+	_, err := uc.GetCategoryItems(context.Background(), "2084261685", 1, model.CategorySearchOptions{})
 	if !errors.Is(err, repoErr) {
 		t.Errorf("got error %v, want %v", err, repoErr)
 	}
 }
+
+// multiCategoryRepo はカテゴリIDごとに異なる結果を返すフェイクです
+type multiCategoryRepo struct {
+	pages map[string]*model.CategoryItemsPage
+	errs  map[string]error
+}
+
+func (f multiCategoryRepo) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if err := f.errs[categoryID]; err != nil {
+		return nil, err
+	}
+	return f.pages[categoryID], nil
+}
+
+func (f multiCategoryRepo) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	return f.FetchByCategory(ctx, categoryID, offset/limit, opts)
+}
+
+func (f multiCategoryRepo) FetchItemCount(ctx context.Context, categoryID string) (int64, error) {
+	p, err := f.FetchByCategory(ctx, categoryID, 0, model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return p.TotalCount, nil
+}
+
+func TestCategoryUsecase_GetMultiCategoryItems_mergesAndDedups(t *testing.T) {
+	t.Parallel()
+
+	repo := multiCategoryRepo{pages: map[string]*model.CategoryItemsPage{
+		"1": {
+			Items:      []*model.CategoryItem{{AuctionID: "a"}, {AuctionID: "b"}},
+			TotalCount: 2,
+		},
+		"2": {
+			Items:      []*model.CategoryItem{{AuctionID: "b"}, {AuctionID: "c"}},
+			TotalCount: 10,
+			HasNext:    true,
+		},
+	}}
+	uc := NewCategoryUsecase(repo)
+
+	got, err := uc.GetMultiCategoryItems(context.Background(), []string{"1", "2"}, 0, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, item := range got.Items {
+		ids = append(ids, item.AuctionID)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("AuctionIDs got %v, want %v", ids, want)
+	}
+	if got.TotalCount != 12 {
+		t.Errorf("TotalCount got %d, want 12", got.TotalCount)
+	}
+	if !got.HasNext {
+		t.Errorf("HasNext got false, want true")
+	}
+}
+
+func TestCategoryUsecase_GetMultiCategoryItems_mergesSucceededCategories(t *testing.T) {
+	t.Parallel()
+
+	repoErr := errors.New("repo error")
+	repo := multiCategoryRepo{
+		pages: map[string]*model.CategoryItemsPage{
+			"1": {Items: []*model.CategoryItem{{AuctionID: "a"}}, TotalCount: 1},
+			"3": {NoResults: true},
+		},
+		errs: map[string]error{"2": repoErr},
+	}
+	uc := NewCategoryUsecase(repo)
+
+	got, err := uc.GetMultiCategoryItems(context.Background(), []string{"1", "2", "3"}, 0, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].AuctionID != "a" {
+		t.Errorf("items got %+v, want only a", got.Items)
+	}
+	if !got.Partial {
+		t.Error("Partial got false, want true")
+	}
+	if want := []string{"2"}; !reflect.DeepEqual(got.FailedCategoryIDs, want) {
+		t.Errorf("FailedCategoryIDs got %v, want %v", got.FailedCategoryIDs, want)
+	}
+}
+
+func TestCategoryUsecase_GetMultiCategoryItems_returnsErrorWhenAllFail(t *testing.T) {
+	t.Parallel()
+
+	repoErr := errors.New("repo error")
+	repo := multiCategoryRepo{errs: map[string]error{"1": repoErr, "2": repoErr}}
+	uc := NewCategoryUsecase(repo)
+
+	_, err := uc.GetMultiCategoryItems(context.Background(), []string{"1", "2"}, 0, model.CategorySearchOptions{})
+	if !errors.Is(err, repoErr) {
+		t.Errorf("got error %v, want %v", err, repoErr)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_validatesCategoryID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		categoryID string
+		wantErr    bool
+	}{
+		{name: "numeric", categoryID: "2084261685", wantErr: false},
+		{name: "surrounding spaces", categoryID: " 2084261685 ", wantErr: false},
+		{name: "empty", categoryID: "", wantErr: true},
+		{name: "spaces only", categoryID: "   ", wantErr: true},
+		{name: "alphabetic", categoryID: "camera", wantErr: true},
+		{name: "mixed", categoryID: "2084a61685", wantErr: true},
+		{name: "negative", categoryID: "-1", wantErr: true},
+		{name: "path traversal", categoryID: "../2084261685", wantErr: true},
+		{name: "full-width digits", categoryID: "２０８４", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotID string
+			repo := recordingCategoryRepo{gotID: &gotID}
+			uc := NewCategoryUsecase(repo)
+
+			_, err := uc.GetCategoryItems(context.Background(), tc.categoryID, 0, model.CategorySearchOptions{})
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Fatalf("got error %v, want %v", err, ErrInvalidArgument)
+				}
+				if gotID != "" {
+					t.Fatalf("repository must not be called for invalid id, got %q", gotID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotID != "2084261685" {
+				t.Fatalf("normalized id got %q, want %q", gotID, "2084261685")
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetMultiCategoryItems_validatesCategoryIDs(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(multiCategoryRepo{})
+
+	_, err := uc.GetMultiCategoryItems(context.Background(), []string{"1", "abc"}, 0, model.CategorySearchOptions{})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_normalizesSearchOptions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotID   string
+		gotOpts model.CategorySearchOptions
+	)
+	uc := NewCategoryUsecase(recordingCategoryRepo{gotID: &gotID, gotOpts: &gotOpts})
+
+	opts := model.CategorySearchOptions{
+		Keyword:         "  ニコン ",
+		ExcludeKeywords: []string{" ジャンク", "", "  "},
+	}
+	if _, err := uc.GetCategoryItems(context.Background(), "2084261685", 0, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := model.CategorySearchOptions{Keyword: "ニコン", ExcludeKeywords: []string{"ジャンク"}}
+	if !reflect.DeepEqual(gotOpts, want) {
+		t.Errorf("opts got %+v, want %+v", gotOpts, want)
+	}
+}
+
+// recordingCategoryRepo は FetchByCategory に渡されたカテゴリIDと検索条件を記録するフェイクです
+type recordingCategoryRepo struct {
+	gotID   *string
+	gotOpts *model.CategorySearchOptions
+}
+
+func (f recordingCategoryRepo) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	*f.gotID = categoryID
+	if f.gotOpts != nil {
+		*f.gotOpts = opts
+	}
+	return &model.CategoryItemsPage{}, nil
+}
+
+func (f recordingCategoryRepo) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	return f.FetchByCategory(ctx, categoryID, offset/limit, opts)
+}
+
+func (f recordingCategoryRepo) FetchItemCount(ctx context.Context, categoryID string) (int64, error) {
+	p, err := f.FetchByCategory(ctx, categoryID, 0, model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return p.TotalCount, nil
+}
+
+// pagedCategoryRepo はページ番号ごとに異なる結果を返すフェイクです
+type pagedCategoryRepo struct {
+	pages   []*model.CategoryItemsPage
+	fetched *[]int64
+}
+
+func (f pagedCategoryRepo) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	if f.fetched != nil {
+		*f.fetched = append(*f.fetched, page)
+	}
+	return f.pages[page], nil
+}
+
+func (f pagedCategoryRepo) FetchByCategoryOffset(ctx context.Context, categoryID string, offset, limit int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	return f.FetchByCategory(ctx, categoryID, offset/limit, opts)
+}
+
+func (f pagedCategoryRepo) FetchItemCount(ctx context.Context, categoryID string) (int64, error) {
+	p, err := f.FetchByCategory(ctx, categoryID, 0, model.CategorySearchOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return p.TotalCount, nil
+}
+
+func TestCategoryUsecase_GetCategoryItemsRange_stopsWhenNoNextPage(t *testing.T) {
+	t.Parallel()
+
+	var fetched []int64
+	repo := pagedCategoryRepo{
+		pages: []*model.CategoryItemsPage{
+			{Items: []*model.CategoryItem{{AuctionID: "a"}}, TotalCount: 2, HasNext: true},
+			{Items: []*model.CategoryItem{{AuctionID: "b"}}, TotalCount: 2, HasNext: false},
+			{Items: []*model.CategoryItem{{AuctionID: "c"}}},
+		},
+		fetched: &fetched,
+	}
+	uc := NewCategoryUsecase(repo, WithPageDelay(0, 0))
+
+	got, err := uc.GetCategoryItemsRange(context.Background(), "1", 0, 2, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fetched, []int64{0, 1}) {
+		t.Errorf("fetched pages got %v, want [0 1]", fetched)
+	}
+	if len(got.Items) != 2 || got.TotalCount != 2 || got.HasNext {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItemsRange_respectsCancellationDuringDelay(t *testing.T) {
+	t.Parallel()
+
+	repo := pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+		{HasNext: true},
+		{HasNext: false},
+	}}
+	uc := NewCategoryUsecase(repo, WithPageDelay(time.Hour, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := uc.GetCategoryItemsRange(ctx, "1", 0, 1, model.CategorySearchOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItemsRange_aggregateTimeout(t *testing.T) {
+	t.Parallel()
+
+	repo := pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+		{Items: []*model.CategoryItem{{AuctionID: "a"}}, TotalCount: 3, HasNext: true},
+		{Items: []*model.CategoryItem{{AuctionID: "b"}}, TotalCount: 3, HasNext: true},
+		{Items: []*model.CategoryItem{{AuctionID: "c"}}, TotalCount: 3},
+	}}
+	uc := NewCategoryUsecase(repo, WithPageDelay(time.Hour, time.Hour), WithAggregateTimeout(10*time.Millisecond))
+
+	got, err := uc.GetCategoryItemsRange(context.Background(), "1", 0, 2, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Truncated {
+		t.Errorf("Truncated got false, want true")
+	}
+	if len(got.Items) != 1 || got.Items[0].AuctionID != "a" || !got.HasNext {
+		t.Errorf("got %+v, want only the first page with HasNext", got)
+	}
+}
+
+func TestCategoryUsecase_EachCategoryPage_aggregateTimeout(t *testing.T) {
+	t.Parallel()
+
+	repo := pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+		{Items: []*model.CategoryItem{{AuctionID: "a"}}, HasNext: true},
+		{Items: []*model.CategoryItem{{AuctionID: "b"}}},
+	}}
+	uc := NewCategoryUsecase(repo, WithPageDelay(time.Hour, time.Hour), WithAggregateTimeout(10*time.Millisecond))
+
+	var pages []int64
+	err := uc.EachCategoryPage(context.Background(), "1", 0, 1, model.CategorySearchOptions{}, func(page int64, p *model.CategoryItemsPage) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("got error %v, want %v", err, ErrTruncated)
+	}
+	if !reflect.DeepEqual(pages, []int64{0}) {
+		t.Errorf("pages got %v, want [0]", pages)
+	}
+
+	// 呼び出し元のキャンセルは打ち切りとして扱わない
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	uc = NewCategoryUsecase(repo, WithPageDelay(time.Hour, time.Hour), WithAggregateTimeout(time.Hour))
+	err = uc.EachCategoryPage(ctx, "1", 0, 1, model.CategorySearchOptions{}, func(int64, *model.CategoryItemsPage) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTruncated) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// flakyPageRepo は各ページの1回目の取得では flaky の結果を、2回目以降は pages の結果を返すフェイクです
+type flakyPageRepo struct {
+	pagedCategoryRepo
+	flaky map[int64]*model.CategoryItemsPage
+	calls map[int64]int
+}
+
+func (f flakyPageRepo) FetchByCategory(ctx context.Context, categoryID string, page int64, opts model.CategorySearchOptions) (*model.CategoryItemsPage, error) {
+	f.calls[page]++
+	if p, ok := f.flaky[page]; ok && f.calls[page] == 1 {
+		return p, nil
+	}
+	return f.pagedCategoryRepo.FetchByCategory(ctx, categoryID, page, opts)
+}
+
+func TestCategoryUsecase_GetCategoryItemsRange_shortPageRetry(t *testing.T) {
+	t.Parallel()
+
+	newRepo := func() flakyPageRepo {
+		return flakyPageRepo{
+			pagedCategoryRepo: pagedCategoryRepo{pages: []*model.CategoryItemsPage{
+				{Items: []*model.CategoryItem{{AuctionID: "a"}, {AuctionID: "b"}}, HasNext: true},
+				{Items: []*model.CategoryItem{{AuctionID: "c"}}},
+			}},
+			flaky: map[int64]*model.CategoryItemsPage{
+				0: {Items: []*model.CategoryItem{{AuctionID: "a"}}, HasNext: true},
+			},
+			calls: map[int64]int{},
+		}
+	}
+
+	repo := newRepo()
+	uc := NewCategoryUsecase(repo, WithPageDelay(0, 0), WithShortPageRetry(2))
+	got, err := uc.GetCategoryItemsRange(context.Background(), "1", 0, 1, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 3 {
+		t.Errorf("items got %d, want 3", len(got.Items))
+	}
+	// 最後のページ（HasNext が false）は商品数が足りなくても取得し直さない
+	if !reflect.DeepEqual(repo.calls, map[int64]int{0: 2, 1: 1}) {
+		t.Errorf("calls got %v, want map[0:2 1:1]", repo.calls)
+	}
+
+	// 設定しない場合は取得し直さない
+	repo = newRepo()
+	uc = NewCategoryUsecase(repo, WithPageDelay(0, 0))
+	got, err = uc.GetCategoryItemsRange(context.Background(), "1", 0, 1, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 2 || repo.calls[0] != 1 {
+		t.Errorf("items got %d, calls got %v; want 2 items without retry", len(got.Items), repo.calls)
+	}
+}
+
+// TestCategoryUsecase_GetCategoryItemsRange_shortPageRetryWithScraper は実際のスクレイパーで、商品数が足りないページが
+// 取得し直されることを確認します。スクレイパーの HasNext は商品数による簡易判定のため、商品数が足りないページでは false になります
+func TestCategoryUsecase_GetCategoryItemsRange_shortPageRetryWithScraper(t *testing.T) {
+	t.Parallel()
+
+	const total = 60
+	listing := func(offset, n int) string {
+		var b strings.Builder
+		b.WriteString(`<html><body><div class="Result__header"><div class="SearchMode"><div class="Tab"><ul>` +
+			`<li class="Tab__item Tab__item--current"><div><span class="Tab__subText">` + strconv.Itoa(total) + `件</span></div></li>` +
+			`</ul></div></div></div><div class="Products__list"><ul class="Products__items">`)
+		for i := offset; i < offset+n; i++ {
+			fmt.Fprintf(&b, `<li class="Product"><h3 class="Product__title"><a class="Product__titleLink" data-auction-id="a%d">item</a></h3></li>`, i)
+		}
+		b.WriteString(`</ul></div></body></html>`)
+		return b.String()
+	}
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := r.URL.Query().Get("b")
+		mu.Lock()
+		calls[b]++
+		n := calls[b]
+		mu.Unlock()
+
+		offset, _ := strconv.Atoi(b)
+		offset--
+		count := min(int(yahoo.CategoryItemsPerPage), total-offset)
+		if offset == 0 && n == 1 {
+			// 1ページ目の最初の取得だけ、取得元が一部の商品しか返さない
+			count = 10
+		}
+		_, _ = w.Write([]byte(listing(offset, count)))
+	}))
+	defer srv.Close()
+
+	repo := yahoo.NewYahooCategoryScraper(yahoo.WithBaseURL(srv.URL), yahoo.WithHTTPClient(srv.Client()))
+	uc := NewCategoryUsecase(repo, WithPageDelay(0, 0), WithShortPageRetry(yahoo.CategoryItemsPerPage))
+	got, err := uc.GetCategoryItemsRange(context.Background(), "1", 0, 1, model.CategorySearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != total {
+		t.Errorf("items got %d, want %d", len(got.Items), total)
+	}
+	// 最後のページは商品数が足りなくても後続の商品がないため取得し直さない
+	if want := map[string]int{"1": 2, "51": 1}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls got %v, want %v", calls, want)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItemsRange_rejectsInvalidRange(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(pagedCategoryRepo{}, WithPageDelay(0, 0))
+
+	_, err := uc.GetCategoryItemsRange(context.Background(), "1", 2, 1, model.CategorySearchOptions{})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestJitter_staysWithinRange(t *testing.T) {
+	t.Parallel()
+
+	lo, hi := 10*time.Millisecond, 20*time.Millisecond
+	for i := 0; i < 100; i++ {
+		if d := jitter(lo, hi); d < lo || d > hi {
+			t.Fatalf("jitter got %v, want within [%v, %v]", d, lo, hi)
+		}
+	}
+	if d := jitter(hi, lo); d != hi {
+		t.Fatalf("jitter with inverted range got %v, want %v", d, hi)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_endingSoonExcludesEndedAndSorts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 30, 12, 0, 0, 0, time.UTC)
+	repo := fakeCategoryRepo{page: &model.CategoryItemsPage{
+		Items: []*model.CategoryItem{
+			{AuctionID: "later", EndTime: now.Add(3 * time.Hour)},
+			{AuctionID: "unknown"},
+			{AuctionID: "ended", EndTime: now.Add(-time.Minute)},
+			{AuctionID: "soonest", EndTime: now.Add(10 * time.Minute)},
+			{AuctionID: "soon", EndTime: now.Add(time.Hour)},
+		},
+	}}
+	uc := NewCategoryUsecase(repo)
+	uc.now = func() time.Time { return now }
+
+	got, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{EndingSoon: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, item := range got.Items {
+		ids = append(ids, item.AuctionID)
+	}
+	if want := []string{"soonest", "soon", "later", "unknown"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("AuctionIDs got %v, want %v", ids, want)
+	}
+	for _, item := range got.Items[1:] {
+		if !item.EndTime.IsZero() && item.EndTime.Before(got.Items[0].EndTime) {
+			t.Fatalf("first item EndTime %v is not the earliest (found %v)", got.Items[0].EndTime, item.EndTime)
+		}
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_rejectsInvalidNewlyListedWithin(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(fakeCategoryRepo{page: &model.CategoryItemsPage{}})
+
+	for _, within := range []time.Duration{-time.Hour, model.MaxNewlyListedWithin + time.Second, 7 * 24 * time.Hour} {
+		_, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{NewlyListedWithin: within})
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("within %v: got error %v, want %v", within, err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_newlyListedWithin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := fakeCategoryRepo{page: &model.CategoryItemsPage{
+		// 取得元の新着フィルタ（24時間以内）が適用された一覧を想定する
+		Items: []*model.CategoryItem{
+			{AuctionID: "recent", StartTime: now.Add(-30 * time.Minute)},
+			{AuctionID: "boundary", StartTime: now.Add(-time.Hour)},
+			{AuctionID: "older", StartTime: now.Add(-5 * time.Hour)},
+			{AuctionID: "unknown"},
+		},
+		TotalCount: 4,
+	}}
+	uc := NewCategoryUsecase(repo)
+	uc.now = func() time.Time { return now }
+
+	got, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{NewlyListedWithin: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, item := range got.Items {
+		ids = append(ids, item.AuctionID)
+	}
+	if want := []string{"recent", "boundary", "unknown"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+	if len(repo.page.Items) != 4 {
+		t.Errorf("fetched page must not be modified")
+	}
+
+	got, err = uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{NewlyListedWithin: model.MaxNewlyListedWithin})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 4 {
+		t.Errorf("got %d items, want all 4 within %v", len(got.Items), model.MaxNewlyListedWithin)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItemsByOffset(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		offset   int64
+		limit    int64
+		wantPage int64
+		wantErr  error
+	}{
+		{name: "aligned offset", offset: 100, limit: 50, wantPage: 2},
+		{name: "limit 20", offset: 40, limit: 20, wantPage: 2},
+		{name: "negative offset", offset: -1, limit: 50, wantErr: ErrInvalidArgument},
+		{name: "unsupported limit", offset: 0, limit: 30, wantErr: ErrInvalidArgument},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fetched []int64
+			pages := make([]*model.CategoryItemsPage, 3)
+			for i := range pages {
+				pages[i] = &model.CategoryItemsPage{}
+			}
+			uc := NewCategoryUsecase(pagedCategoryRepo{pages: pages, fetched: &fetched})
+
+			_, err := uc.GetCategoryItemsByOffset(context.Background(), "2084005", tc.offset, tc.limit, model.CategorySearchOptions{})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				if len(fetched) != 0 {
+					t.Fatalf("repo should not be called, fetched %v", fetched)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(fetched) != 1 || fetched[0] != tc.wantPage {
+				t.Fatalf("fetched got %v, want [%d]", fetched, tc.wantPage)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_minSellerRating(t *testing.T) {
+	t.Parallel()
+
+	page := &model.CategoryItemsPage{
+		Items: []*model.CategoryItem{
+			{AuctionID: "good"},
+			{AuctionID: "bad"},
+			{AuctionID: "unrated"},
+			{AuctionID: "missing"},
+		},
+		TotalCount: 4,
+	}
+	items := memory.NewItemRepository(
+		&model.Item{AuctionID: "good", Seller: &model.Seller{RatingPercentage: 99.5}},
+		&model.Item{AuctionID: "bad", Seller: &model.Seller{RatingPercentage: 90}},
+		&model.Item{AuctionID: "unrated", Seller: &model.Seller{}},
+	)
+	uc := NewCategoryUsecase(fakeCategoryRepo{page: page}, WithSellerLookup(items))
+
+	got, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{MinSellerRatingPercentage: 98})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].AuctionID != "good" {
+		t.Fatalf("items got %+v, want only good", got.Items)
+	}
+	if got.TotalCount != 4 {
+		t.Errorf("TotalCount got %d, want 4", got.TotalCount)
+	}
+	if !got.Partial {
+		t.Error("Partial got false, want true because the lookup for missing failed")
+	}
+	if len(page.Items) != 4 {
+		t.Errorf("source page was modified: %d items", len(page.Items))
+	}
+}
+
+// sellerLookupRepo は出品者の評価の取得で、err を返すか delay だけ待機するフェイクです
+type sellerLookupRepo struct {
+	repository.ItemRepository
+
+	err   error
+	delay time.Duration
+}
+
+func (f sellerLookupRepo) FetchByIDWithFields(ctx context.Context, auctionID string, fields model.ItemFields) (*model.Item, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(f.delay):
+	}
+	return &model.Item{AuctionID: auctionID, Seller: &model.Seller{RatingPercentage: 100}}, nil
+}
+
+func TestCategoryUsecase_GetCategoryItems_minSellerRatingLookupFailures(t *testing.T) {
+	t.Parallel()
+
+	page := &model.CategoryItemsPage{
+		Items:      []*model.CategoryItem{{AuctionID: "x1"}, {AuctionID: "x2"}},
+		TotalCount: 2,
+	}
+	opts := model.CategorySearchOptions{MinSellerRatingPercentage: 98}
+
+	t.Run("all lookups failed", func(t *testing.T) {
+		t.Parallel()
+
+		uc := NewCategoryUsecase(fakeCategoryRepo{page: page}, WithSellerLookup(sellerLookupRepo{err: repository.ErrRateLimited}))
+		_, err := uc.GetCategoryItems(context.Background(), "1", 0, opts)
+		if !errors.Is(err, repository.ErrRateLimited) {
+			t.Errorf("got error %v, want %v", err, repository.ErrRateLimited)
+		}
+	})
+
+	t.Run("lookup timeout", func(t *testing.T) {
+		t.Parallel()
+
+		uc := NewCategoryUsecase(fakeCategoryRepo{page: page},
+			WithSellerLookup(sellerLookupRepo{delay: time.Second}),
+			WithSellerLookupTimeout(20*time.Millisecond),
+		)
+		start := time.Now()
+		_, err := uc.GetCategoryItems(context.Background(), "1", 0, opts)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("lookup took %s, want it to stop at the timeout", elapsed)
+		}
+	})
+}
+
+func TestCategoryUsecase_GetCategoryItems_minSellerRatingValidation(t *testing.T) {
+	t.Parallel()
+
+	page := &model.CategoryItemsPage{Items: []*model.CategoryItem{{AuctionID: "x1"}}}
+
+	cases := []struct {
+		name string
+		uc   *CategoryUsecase
+		min  float64
+	}{
+		{name: "out of range", uc: NewCategoryUsecase(fakeCategoryRepo{page: page}, WithSellerLookup(memory.NewItemRepository())), min: 101},
+		{name: "no seller lookup", uc: NewCategoryUsecase(fakeCategoryRepo{page: page}), min: 90},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tc.uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{MinSellerRatingPercentage: tc.min})
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItemCount(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(fakeCategoryRepo{page: &model.CategoryItemsPage{TotalCount: 1234}})
+
+	got, err := uc.GetCategoryItemCount(context.Background(), " 2084005 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 1234 {
+		t.Errorf("got %d, want 1234", got)
+	}
+
+	if _, err := uc.GetCategoryItemCount(context.Background(), "abc"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestCategoryUsecase_categoryAccessLists(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		opts       []CategoryOption
+		categoryID string
+		wantErr    bool
+	}{
+		{name: "allow all by default", categoryID: "2084005"},
+		{name: "in allow list", opts: []CategoryOption{WithAllowedCategories([]string{"2084005", "2084261685"})}, categoryID: "2084005"},
+		{name: "not in allow list", opts: []CategoryOption{WithAllowedCategories([]string{"2084261685"})}, categoryID: "2084005", wantErr: true},
+		{name: "in deny list", opts: []CategoryOption{WithDeniedCategories([]string{"2084005"})}, categoryID: " 2084005 ", wantErr: true},
+		{name: "not in deny list", opts: []CategoryOption{WithDeniedCategories([]string{"2084261685"})}, categoryID: "2084005"},
+		{
+			name:       "deny takes precedence over allow",
+			opts:       []CategoryOption{WithAllowedCategories([]string{"2084005"}), WithDeniedCategories([]string{"2084005"})},
+			categoryID: "2084005",
+			wantErr:    true,
+		},
+		{name: "empty allow list allows all", opts: []CategoryOption{WithAllowedCategories([]string{" ", ""})}, categoryID: "2084005"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotID string
+			uc := NewCategoryUsecase(recordingCategoryRepo{gotID: &gotID}, tc.opts...)
+
+			calls := map[string]func() error{
+				"GetCategoryItems": func() error {
+					_, err := uc.GetCategoryItems(context.Background(), tc.categoryID, 0, model.CategorySearchOptions{})
+					return err
+				},
+				"GetCategoryItemCount": func() error {
+					_, err := uc.GetCategoryItemCount(context.Background(), tc.categoryID)
+					return err
+				},
+				"GetMultiCategoryItems": func() error {
+					_, err := uc.GetMultiCategoryItems(context.Background(), []string{tc.categoryID}, 0, model.CategorySearchOptions{})
+					return err
+				},
+				"EachCategoryPage": func() error {
+					return uc.EachCategoryPage(context.Background(), tc.categoryID, 0, 0, model.CategorySearchOptions{}, func(int64, *model.CategoryItemsPage) error { return nil })
+				},
+			}
+			for name, call := range calls {
+				gotID = ""
+				err := call()
+				if tc.wantErr {
+					if !errors.Is(err, ErrPermissionDenied) {
+						t.Fatalf("%s: got error %v, want %v", name, err, ErrPermissionDenied)
+					}
+					if gotID != "" {
+						t.Fatalf("%s: repository must not be called for denied category, got %q", name, gotID)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_sortBy(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	page := &model.CategoryItemsPage{
+		Items: []*model.CategoryItem{
+			{AuctionID: "a", CurrentPrice: 300, BidCount: 5, EndTime: end.Add(2 * time.Hour)},
+			{AuctionID: "b", CurrentPrice: 100, BidCount: 0},
+			{AuctionID: "c", CurrentPrice: 200, BidCount: 5, EndTime: end.Add(time.Hour)},
+		},
+		TotalCount: 3,
+	}
+
+	cases := []struct {
+		key  model.SortKey
+		want []string
+	}{
+		{key: model.SortKeyNone, want: []string{"a", "b", "c"}},
+		{key: model.SortKeyPriceAsc, want: []string{"b", "c", "a"}},
+		{key: model.SortKeyPriceDesc, want: []string{"a", "c", "b"}},
+		{key: model.SortKeyBidCountAsc, want: []string{"b", "a", "c"}},
+		{key: model.SortKeyBidCountDesc, want: []string{"a", "c", "b"}},
+		{key: model.SortKeyEndTimeAsc, want: []string{"c", "a", "b"}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.key.String(), func(t *testing.T) {
+			t.Parallel()
+
+			uc := NewCategoryUsecase(fakeCategoryRepo{page: page})
+			got, err := uc.GetCategoryItems(context.Background(), "2084261685", 0, model.CategorySearchOptions{SortBy: tc.key})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ids := make([]string, len(got.Items))
+			for i, item := range got.Items {
+				ids[i] = item.AuctionID
+			}
+			if !reflect.DeepEqual(ids, tc.want) {
+				t.Errorf("order got %v, want %v", ids, tc.want)
+			}
+			if got.TotalCount != page.TotalCount {
+				t.Errorf("TotalCount got %d, want %d", got.TotalCount, page.TotalCount)
+			}
+			if page.Items[0].AuctionID != "a" || page.Items[1].AuctionID != "b" {
+				t.Errorf("repository page must not be modified")
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_rejectsUnknownSortKey(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(fakeCategoryRepo{page: &model.CategoryItemsPage{}})
+	_, err := uc.GetCategoryItems(context.Background(), "2084261685", 0, model.CategorySearchOptions{SortBy: model.SortKey(99)})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_rejectsUnknownSellerType(t *testing.T) {
+	t.Parallel()
+
+	uc := NewCategoryUsecase(fakeCategoryRepo{page: &model.CategoryItemsPage{}})
+	_, err := uc.GetCategoryItems(context.Background(), "2084261685", 0, model.CategorySearchOptions{SellerType: model.SellerType(99)})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestCategoryUsecase_GetCategoryItems_maxImmediatePriceRatio(t *testing.T) {
+	t.Parallel()
+
+	repo := fakeCategoryRepo{page: &model.CategoryItemsPage{
+		Items: []*model.CategoryItem{
+			{AuctionID: "no-immediate", CurrentPrice: 100},
+			{AuctionID: "bargain", CurrentPrice: 400, ImmediatePrice: 1000},
+			{AuctionID: "boundary", CurrentPrice: 500, ImmediatePrice: 1000},
+			{AuctionID: "expensive", CurrentPrice: 900, ImmediatePrice: 1000},
+		},
+		TotalCount: 4,
+	}}
+	uc := NewCategoryUsecase(repo)
+
+	got, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{MaxImmediatePriceRatio: 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].AuctionID != "bargain" {
+		t.Errorf("got %+v, want only bargain", got.Items)
+	}
+	if got.TotalCount != 4 || len(repo.page.Items) != 4 {
+		t.Errorf("fetched page must not be modified")
+	}
+
+	for _, ratio := range []float64{-0.1, 1.5} {
+		_, err := uc.GetCategoryItems(context.Background(), "1", 0, model.CategorySearchOptions{MaxImmediatePriceRatio: ratio})
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("ratio %v: got error %v, want %v", ratio, err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestMergeCategoryPages_noResults(t *testing.T) {
+	t.Parallel()
+
+	empty := &model.CategoryItemsPage{NoResults: true}
+	nonEmpty := &model.CategoryItemsPage{Items: []*model.CategoryItem{{AuctionID: "a"}}, TotalCount: 1}
+
+	if got := mergeCategoryPages([]*model.CategoryItemsPage{empty, empty}); !got.NoResults {
+		t.Errorf("NoResults got false, want true when every category has no results")
+	}
+	if got := mergeCategoryPages([]*model.CategoryItemsPage{empty, nonEmpty}); got.NoResults {
+		t.Errorf("NoResults got true, want false when any category has results")
+	}
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import "errors"
+
+// ErrInvalidArgument は入力値が不正な場合に返されます
+// 外部へのリクエストを行う前に検出されるため、handler層ではクライアントエラーとして扱います
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrPermissionDenied は設定により取得が許可されていない対象を指定した場合に返されます
+// ErrInvalidArgument と同様に外部へのリクエストを行う前に検出されます
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrTruncated は複数ページの取得が時間の上限に達し、途中で打ち切られた場合に返されます
+// それまでに取得したページは呼び出し元に渡し済みです
+var ErrTruncated = errors.New("aggregate operation truncated")
+
+// ErrIteratorDone は CategoryIterator がすべての商品を返し終えた場合に Next が返します
+// io.EOF と同様に終了を表す値であり、エラーとして扱う必要はありません
+var ErrIteratorDone = errors.New("no more items")
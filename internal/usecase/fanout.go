@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+// fanOut は ids のそれぞれについて fetch を並行して呼び出し、入力と同じ順序で結果を返します
+// 同時に呼び出す数は limit までに制限します。1件の失敗で他の取得は中断せず、その結果の Err に記録します
+// ctx がキャンセルされた場合、未処理の入力の結果は ctx.Err() となります
+func fanOut[T any](ctx context.Context, ids []string, limit int, fetch func(ctx context.Context, id string) (T, error)) []model.Result[T] {
+	results := make([]model.Result[T], len(ids))
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		results[i].ID = id
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].Err = ctx.Err()
+				return
+			}
+
+			v, err := fetch(ctx, id)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Value = v
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+	"jo3qma.com/yahoo_auctions/internal/domain/repository"
+)
+
+// SellerUsecase は出品者関連のビジネスロジックを担当します
+type SellerUsecase struct {
+	repo repository.SellerRepository
+}
+
+// NewSellerUsecase は新しいSellerUsecaseインスタンスを作成します
+func NewSellerUsecase(repo repository.SellerRepository) *SellerUsecase {
+	return &SellerUsecase{
+		repo: repo,
+	}
+}
+
+// GetSellerRating は指定された出品者IDの評価の内訳を取得します
+// 評価がない出品者の場合、各件数はすべて0になります
+func (u *SellerUsecase) GetSellerRating(ctx context.Context, sellerID string) (*model.SellerRating, error) {
+	sellerID = strings.TrimSpace(sellerID)
+	if sellerID == "" {
+		return nil, fmt.Errorf("seller id is required: %w", ErrInvalidArgument)
+	}
+	return u.repo.FetchSellerRating(ctx, sellerID)
+}
+
+// GetSellerItemCount は指定された出品者IDの出品中の商品の総数を取得します
+// 出品中の商品がない出品者の場合は0を返します
+func (u *SellerUsecase) GetSellerItemCount(ctx context.Context, sellerID string) (int64, error) {
+	sellerID = strings.TrimSpace(sellerID)
+	if sellerID == "" {
+		return 0, fmt.Errorf("seller id is required: %w", ErrInvalidArgument)
+	}
+	return u.repo.FetchSellerItemCount(ctx, sellerID)
+}
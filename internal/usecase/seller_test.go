@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jo3qma.com/yahoo_auctions/internal/domain/model"
+)
+
+type fakeSellerRepo struct {
+	gotID string
+	count int64
+}
+
+func (f *fakeSellerRepo) FetchSellerRating(ctx context.Context, sellerID string) (*model.SellerRating, error) {
+	f.gotID = sellerID
+	return &model.SellerRating{SellerID: sellerID}, nil
+}
+
+func (f *fakeSellerRepo) FetchSellerItemCount(ctx context.Context, sellerID string) (int64, error) {
+	f.gotID = sellerID
+	return f.count, nil
+}
+
+func TestSellerUsecase_GetSellerRating(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		id      string
+		wantID  string
+		wantErr error
+	}{
+		{name: "trims id", id: " seller1 ", wantID: "seller1"},
+		{name: "empty id", id: "  ", wantErr: ErrInvalidArgument},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeSellerRepo{}
+			uc := NewSellerUsecase(repo)
+
+			got, err := uc.GetSellerRating(context.Background(), tc.id)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.gotID != tc.wantID || got.SellerID != tc.wantID {
+				t.Fatalf("seller id got %q, want %q", repo.gotID, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestSellerUsecase_GetSellerItemCount(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		id      string
+		count   int64
+		wantID  string
+		wantErr error
+	}{
+		{name: "trims id", id: " seller1 ", count: 12, wantID: "seller1"},
+		{name: "no listings", id: "seller1", wantID: "seller1"},
+		{name: "empty id", id: "  ", wantErr: ErrInvalidArgument},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeSellerRepo{count: tc.count}
+			uc := NewSellerUsecase(repo)
+
+			got, err := uc.GetSellerItemCount(context.Background(), tc.id)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.gotID != tc.wantID {
+				t.Fatalf("seller id got %q, want %q", repo.gotID, tc.wantID)
+			}
+			if got != tc.count {
+				t.Fatalf("count got %d, want %d", got, tc.count)
+			}
+		})
+	}
+}